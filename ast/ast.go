@@ -26,6 +26,12 @@ type Expression interface {
 	expressionNode()
 }
 
+// An extension of the Node interface. A Pattern is matched against a value and may bind names while doing so.
+type Pattern interface {
+	Node
+	patternNode()
+}
+
 // This struct represents the root node of our AST.
 type Program struct {
 	Statements []Statement
@@ -50,9 +56,10 @@ func (p *Program) String() string {
 }
 
 type LetStatement struct {
-	Token token.Token // This will be the LET Token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token // This will be the LET Token
+	Name    *Identifier
+	Pattern Pattern // This is set instead of Name when the binding destructures its value
+	Value   Expression
 }
 
 func (ls *LetStatement) statementNode()       {}
@@ -61,7 +68,11 @@ func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String())
+	} else {
+		out.WriteString(ls.Name.String())
+	}
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
@@ -175,6 +186,38 @@ func (b *Boolean) expressionNode()      {}
 func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
 func (b *Boolean) String() string       { return b.Token.Literal }
 
+type NullLiteral struct {
+	Token token.Token
+}
+
+func (nl *NullLiteral) expressionNode()      {}
+func (nl *NullLiteral) TokenLiteral() string { return nl.Token.Literal }
+func (nl *NullLiteral) String() string       { return nl.Token.Literal }
+
+// This struct represents the ternary conditional: condition ? consequence : alternative
+type ConditionalExpression struct {
+	Token       token.Token // This will be the '?' token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode()      {}
+func (ce *ConditionalExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *ConditionalExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ce.Condition.String())
+	out.WriteString(" ? ")
+	out.WriteString(ce.Consequence.String())
+	out.WriteString(" : ")
+	out.WriteString(ce.Alternative.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
 type IfExpression struct {
 	Token       token.Token // This will be the 'IF' token
 	Condition   Expression
@@ -217,10 +260,12 @@ func (bs *BlockStatement) String() string {
 	return out.String()
 }
 
+// Generator is set when the body contains a yield, in which case calling the function returns a generator
 type FunctionLiteral struct {
 	Token      token.Token
 	Parameters []*Identifier
 	Body       *BlockStatement
+	Generator  bool
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
@@ -275,3 +320,394 @@ type StringLiteral struct {
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
 func (sl *StringLiteral) String() string       { return sl.Token.Literal }
+
+type MatchExpression struct {
+	Token   token.Token // This will be the 'MATCH' token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	out.WriteString("match (")
+	out.WriteString(me.Subject.String())
+	out.WriteString(") { ")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+// A single arm of a match expression. Each arm holds a pattern, an optional guard and a body.
+type MatchArm struct {
+	Token   token.Token // This will be the first token of the arm's pattern
+	Pattern Pattern
+	Guard   Expression
+	Body    *BlockStatement
+}
+
+func (ma *MatchArm) TokenLiteral() string { return ma.Token.Literal }
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ma.Pattern.String())
+
+	if ma.Guard != nil {
+		out.WriteString(" if ")
+		out.WriteString(ma.Guard.String())
+	}
+
+	out.WriteString(" => ")
+	out.WriteString(ma.Body.String())
+
+	return out.String()
+}
+
+// This struct represents a struct declaration such as: struct Point { x, y }
+type StructStatement struct {
+	Token  token.Token // This will be the 'STRUCT' token
+	Name   *Identifier
+	Fields []*Identifier
+}
+
+func (ss *StructStatement) statementNode()       {}
+func (ss *StructStatement) TokenLiteral() string { return ss.Token.Literal }
+func (ss *StructStatement) String() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, f := range ss.Fields {
+		fields = append(fields, f.String())
+	}
+
+	out.WriteString(ss.TokenLiteral() + " ")
+	out.WriteString(ss.Name.String())
+	out.WriteString(" { ")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+// A single 'name: value' entry of a struct literal
+type StructField struct {
+	Name  *Identifier
+	Value Expression
+}
+
+// This struct represents the construction of a struct such as: Point { x: 1, y: 2 }
+// When Base is set the literal copies every field of the base struct that it does not set itself, as in: Point { x: 3, ..p }
+type StructLiteral struct {
+	Token  token.Token // This will be the '{' token
+	Name   Expression
+	Fields []*StructField
+	Base   Expression
+}
+
+func (sl *StructLiteral) expressionNode()      {}
+func (sl *StructLiteral) TokenLiteral() string { return sl.Token.Literal }
+func (sl *StructLiteral) String() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, f := range sl.Fields {
+		fields = append(fields, f.Name.String()+": "+f.Value.String())
+	}
+
+	if sl.Base != nil {
+		fields = append(fields, ".."+sl.Base.String())
+	}
+
+	out.WriteString(sl.Name.String())
+	out.WriteString(" {")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// This struct represents accessing a field with dot syntax such as: p.x
+type SelectorExpression struct {
+	Token token.Token // This will be the '.' token
+	Left  Expression
+	Field *Identifier
+}
+
+func (se *SelectorExpression) expressionNode()      {}
+func (se *SelectorExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SelectorExpression) String() string       { return se.Left.String() + "." + se.Field.String() }
+
+// This struct represents assigning to an existing variable or to a field of an instance, such as: x = 1 or self.n += 1
+type AssignExpression struct {
+	Token    token.Token // This will be the assignment operator token
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *AssignExpression) String() string {
+	return ae.Target.String() + " " + ae.Operator + " " + ae.Value.String()
+}
+
+// A single named method of a class
+type ClassMethod struct {
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+// This struct represents a class declaration such as: class Counter(Base) { init(self) { self.n = 0 } }
+type ClassStatement struct {
+	Token   token.Token // This will be the 'CLASS' token
+	Name    *Identifier
+	Parent  Expression
+	Methods []*ClassMethod
+}
+
+func (cs *ClassStatement) statementNode()       {}
+func (cs *ClassStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ClassStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(cs.TokenLiteral() + " ")
+	out.WriteString(cs.Name.String())
+
+	if cs.Parent != nil {
+		out.WriteString("(" + cs.Parent.String() + ")")
+	}
+
+	out.WriteString(" { ")
+
+	for _, m := range cs.Methods {
+		params := []string{}
+		for _, p := range m.Function.Parameters {
+			params = append(params, p.String())
+		}
+
+		out.WriteString(m.Name.String())
+		out.WriteString("(" + strings.Join(params, ", ") + ") ")
+		out.WriteString("{ " + m.Function.Body.String() + " } ")
+	}
+
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// This struct represents importing a module such as: import "lib/math" as m
+// Alias is nil when the module is bound to the name of its file.
+type ImportStatement struct {
+	Token token.Token // This will be the 'IMPORT' token
+	Path  string
+	Alias *Identifier
+}
+
+func (is *ImportStatement) statementNode()       {}
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+func (is *ImportStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(is.TokenLiteral() + " ")
+	out.WriteString("\"" + is.Path + "\"")
+
+	if is.Alias != nil {
+		out.WriteString(" as " + is.Alias.String())
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// This struct represents a declaration which a module makes available to its importers, such as: export let f = fn() { ... }
+type ExportStatement struct {
+	Token     token.Token // This will be the 'EXPORT' token
+	Statement Statement
+}
+
+func (es *ExportStatement) statementNode()       {}
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Statement.String()
+}
+
+// This struct represents handing a value from a generator to its caller, such as: yield x * 2
+// Value is nil when nothing follows the yield, in which case null is yielded
+type YieldExpression struct {
+	Token token.Token // This will be the 'YIELD' token
+	Value Expression
+}
+
+func (ye *YieldExpression) expressionNode()      {}
+func (ye *YieldExpression) TokenLiteral() string { return ye.Token.Literal }
+func (ye *YieldExpression) String() string {
+	if ye.Value == nil {
+		return "yield"
+	}
+	return "yield " + ye.Value.String()
+}
+
+// This struct represents starting a task, such as: spawn fn() { ... } or spawn fetch(url)
+// Call is either a call expression, whose function and arguments are evaluated before the task starts, or any other expression evaluating to a function
+type SpawnExpression struct {
+	Token token.Token // This will be the 'SPAWN' token
+	Call  Expression
+}
+
+func (se *SpawnExpression) expressionNode()      {}
+func (se *SpawnExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SpawnExpression) String() string {
+	return "spawn " + se.Call.String()
+}
+
+// This struct represents waiting on several channel operations at once, such as:
+// select { receive(ch) as v => v, send(out, 1) => null, _ => "nothing ready" }
+type SelectExpression struct {
+	Token token.Token // This will be the 'SELECT' token
+	Cases []*SelectCase
+}
+
+func (se *SelectExpression) expressionNode()      {}
+func (se *SelectExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SelectExpression) String() string {
+	var out bytes.Buffer
+
+	cases := []string{}
+	for _, c := range se.Cases {
+		cases = append(cases, c.String())
+	}
+
+	out.WriteString("select { ")
+	out.WriteString(strings.Join(cases, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+// This struct represents one case of a select expression
+// Operation is a call to send or receive, or nil for the default case. Binding names the value received, if any.
+type SelectCase struct {
+	Token     token.Token // This will be the first token of the case
+	Operation *CallExpression
+	Binding   *Identifier
+	Body      *BlockStatement
+}
+
+func (sc *SelectCase) String() string {
+	var out bytes.Buffer
+
+	if sc.Operation == nil {
+		out.WriteString("_")
+	} else {
+		out.WriteString(sc.Operation.String())
+	}
+
+	if sc.Binding != nil {
+		out.WriteString(" as " + sc.Binding.String())
+	}
+
+	out.WriteString(" => ")
+	out.WriteString(sc.Body.String())
+
+	return out.String()
+}
+
+type TupleLiteral struct {
+	Token    token.Token // This will be the '(' token
+	Elements []Expression
+}
+
+func (tl *TupleLiteral) expressionNode()      {}
+func (tl *TupleLiteral) TokenLiteral() string { return tl.Token.Literal }
+func (tl *TupleLiteral) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range tl.Elements {
+		elements = append(elements, e.String())
+	}
+
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// The '_' pattern matches any value without binding it.
+type WildcardPattern struct {
+	Token token.Token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) String() string       { return wp.Token.Literal }
+
+// An identifier pattern matches any value and binds it to the identifier's name.
+type IdentifierPattern struct {
+	Token token.Token
+	Name  *Identifier
+}
+
+func (ip *IdentifierPattern) patternNode()         {}
+func (ip *IdentifierPattern) TokenLiteral() string { return ip.Token.Literal }
+func (ip *IdentifierPattern) String() string       { return ip.Name.String() }
+
+// A literal pattern matches a value equal to an integer, string or boolean literal.
+type LiteralPattern struct {
+	Token token.Token
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// A tuple pattern matches a tuple of the same length whose elements match each of the nested patterns.
+type TuplePattern struct {
+	Token    token.Token // This will be the '(' token
+	Elements []Pattern
+}
+
+func (tp *TuplePattern) patternNode()         {}
+func (tp *TuplePattern) TokenLiteral() string { return tp.Token.Literal }
+func (tp *TuplePattern) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range tp.Elements {
+		elements = append(elements, e.String())
+	}
+
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// An alternative pattern matches a value if any one of its alternatives does.
+type AlternativePattern struct {
+	Token        token.Token // This will be the first token of the first alternative
+	Alternatives []Pattern
+}
+
+func (ap *AlternativePattern) patternNode()         {}
+func (ap *AlternativePattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *AlternativePattern) String() string {
+	alternatives := []string{}
+	for _, a := range ap.Alternatives {
+		alternatives = append(alternatives, a.String())
+	}
+
+	return strings.Join(alternatives, " | ")
+}
@@ -1,7 +1,15 @@
 package evaluator
 
-import "github.com/armansandhu/monkey_interpreter/object"
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
 
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// The default built-in functions, which every new Builtins registry starts with
 var builtins = map[string]*object.BuiltIn{
 	"len": &object.BuiltIn{
 		Function: func(args ...object.Object) object.Object {
@@ -18,3 +26,225 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 }
+
+// Methods which can be called on values of a built-in type using dot syntax, such as "abc".upper()
+// Every method receives the value it was called on as its first argument, followed by the arguments of the call.
+var methods = map[object.ObjectType]map[string]*object.BuiltIn{
+	object.STRING_OBJ: {
+		"len": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("len", args, 0); err != nil {
+					return err
+				}
+				return &object.Integer{Value: int64(len(args[0].(*object.String).Value))}
+			},
+		},
+		"upper": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("upper", args, 0); err != nil {
+					return err
+				}
+				return &object.String{Value: strings.ToUpper(args[0].(*object.String).Value)}
+			},
+		},
+		"lower": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("lower", args, 0); err != nil {
+					return err
+				}
+				return &object.String{Value: strings.ToLower(args[0].(*object.String).Value)}
+			},
+		},
+		"split": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("split", args, 1); err != nil {
+					return err
+				}
+
+				separator, ok := args[1].(*object.String)
+				if !ok {
+					return newError("Argument to `split` is not supported! Instead received an %s!", args[1].Type())
+				}
+
+				parts := strings.Split(args[0].(*object.String).Value, separator.Value)
+				elements := make([]object.Object, len(parts))
+				for i, part := range parts {
+					elements[i] = &object.String{Value: part}
+				}
+				return &object.Tuple{Elements: elements}
+			},
+		},
+	},
+	object.INTEGER_OBJ: {
+		"abs": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("abs", args, 0); err != nil {
+					return err
+				}
+
+				value := args[0].(*object.Integer).Value
+				if value == math.MinInt64 {
+					return integerOverflow("abs")
+				}
+				if value < 0 {
+					value = -value
+				}
+				return &object.Integer{Value: value}
+			},
+		},
+	},
+	object.TUPLE_OBJ: {
+		"len": &object.BuiltIn{
+			Function: func(args ...object.Object) object.Object {
+				if err := checkMethodArguments("len", args, 0); err != nil {
+					return err
+				}
+				return &object.Integer{Value: int64(len(args[0].(*object.Tuple).Elements))}
+			},
+		},
+	},
+}
+
+// Builtins is a registry of the built-in functions and methods available to the programs run by one Evaluator.
+// Changing a registry never affects other Evaluators, so embedding code can give each interpreter a different set.
+type Builtins struct {
+	functions map[string]*object.BuiltIn
+	methods   map[object.ObjectType]map[string]*object.BuiltIn
+}
+
+// This function creates a registry with no builtins at all
+func NewBuiltins() *Builtins {
+	return &Builtins{
+		functions: make(map[string]*object.BuiltIn),
+		methods:   make(map[object.ObjectType]map[string]*object.BuiltIn),
+	}
+}
+
+// This function creates a registry holding a copy of the default builtins and methods
+func DefaultBuiltins() *Builtins {
+	return (&Builtins{functions: builtins, methods: methods}).Clone()
+}
+
+// This method copies the registry, so that the copy can be changed without affecting the original
+func (b *Builtins) Clone() *Builtins {
+	clone := NewBuiltins()
+
+	for name, builtin := range b.functions {
+		clone.functions[name] = builtin
+	}
+
+	for objectType, table := range b.methods {
+		clone.methods[objectType] = make(map[string]*object.BuiltIn, len(table))
+		for name, method := range table {
+			clone.methods[objectType][name] = method
+		}
+	}
+
+	return clone
+}
+
+// This method makes a Go function callable by name. Registering a name which already exists shadows the existing builtin.
+// Variables defined by a program still take precedence over builtins of the same name.
+func (b *Builtins) Register(name string, function object.BuiltInFunction) {
+	b.functions[name] = &object.BuiltIn{Function: function}
+}
+
+// This method makes a Go function callable by name like Register, passing it the context of the evaluation calling it.
+// The context is cancelled when the evaluation is, and carries the calling Evaluator for FromContext and CheckStringLength.
+func (b *Builtins) RegisterContext(name string, function object.ContextBuiltInFunction) {
+	b.functions[name] = &object.BuiltIn{ContextFunction: function}
+}
+
+// This method removes a builtin, so that programs can no longer call it
+func (b *Builtins) Remove(name string) {
+	delete(b.functions, name)
+}
+
+// This method returns the builtin registered under name
+func (b *Builtins) Lookup(name string) (*object.BuiltIn, bool) {
+	builtin, ok := b.functions[name]
+	return builtin, ok
+}
+
+// This method lists the names of the registered builtins in alphabetical order
+func (b *Builtins) Names() []string {
+	names := make([]string, 0, len(b.functions))
+	for name := range b.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// This method makes a Go function callable with dot syntax on every value of the given object type.
+// The function receives the value it was called on as its first argument. Registering a method under an existing name replaces it.
+func (b *Builtins) RegisterMethod(objectType object.ObjectType, name string, function object.BuiltInFunction) {
+	if b.methods[objectType] == nil {
+		b.methods[objectType] = make(map[string]*object.BuiltIn)
+	}
+	b.methods[objectType][name] = &object.BuiltIn{Function: function}
+}
+
+// This method removes a method from an object type
+func (b *Builtins) RemoveMethod(objectType object.ObjectType, name string) {
+	delete(b.methods[objectType], name)
+}
+
+// This method returns the method registered under name for an object type
+func (b *Builtins) LookupMethod(objectType object.ObjectType, name string) (*object.BuiltIn, bool) {
+	method, ok := b.methods[objectType][name]
+	return method, ok
+}
+
+// This function makes each method of objectType a builtin as well, which takes the receiver as its first argument.
+// Builtins which already exist, such as len, are left alone.
+func registerMethodFunctions(objectType object.ObjectType) {
+	for name, method := range methods[objectType] {
+		if _, ok := builtins[name]; !ok {
+			builtins[name] = methodFunction(name, objectType, method)
+		}
+	}
+}
+
+// This helper function turns a method into a builtin which receives the value the method is called on as its first argument
+func methodFunction(name string, objectType object.ObjectType, method *object.BuiltIn) *object.BuiltIn {
+	checkReceiver := func(args []object.Object) *object.Error {
+		if len(args) == 0 {
+			return newError("Incorrect number of arguments detected! Needed at least 1 but instead received 0!")
+		}
+
+		if args[0].Type() != objectType {
+			return newError("Argument to `%s` is not supported! Instead received an %s!", name, args[0].Type())
+		}
+
+		return nil
+	}
+
+	if method.ContextFunction != nil {
+		return &object.BuiltIn{
+			ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+				if err := checkReceiver(args); err != nil {
+					return err
+				}
+				return method.ContextFunction(ctx, args...)
+			},
+		}
+	}
+
+	return &object.BuiltIn{
+		Function: func(args ...object.Object) object.Object {
+			if err := checkReceiver(args); err != nil {
+				return err
+			}
+			return method.Function(args...)
+		},
+	}
+}
+
+// This helper function checks that a method received the expected number of arguments, not counting its receiver
+func checkMethodArguments(name string, args []object.Object, expected int) *object.Error {
+	if len(args)-1 != expected {
+		return newError("Incorrect number of arguments to `%s` detected! Only needed %d but instead received %d!", name, expected, len(args)-1)
+	}
+	return nil
+}
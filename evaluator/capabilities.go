@@ -0,0 +1,153 @@
+package evaluator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// Capability names a kind of access to the world outside the interpreter which builtins may need
+type Capability string
+
+const (
+	FS_READ  Capability = "fs-read"
+	FS_WRITE Capability = "fs-write"
+	ENV      Capability = "env"
+	TIME     Capability = "time"
+	RANDOM   Capability = "random"
+	STDOUT   Capability = "stdout"
+)
+
+// every capability, in the order they are listed to users
+var allCapabilities = []Capability{FS_READ, FS_WRITE, ENV, TIME, RANDOM, STDOUT}
+
+// Capabilities is the set of capabilities granted to the programs run by an Evaluator.
+// Builtins which perform I/O check it before acting, and a new set grants nothing.
+type Capabilities struct {
+	granted  map[Capability]bool
+	readable []string
+}
+
+// This function creates a set which grants the given capabilities and nothing else
+func NewCapabilities(capabilities ...Capability) *Capabilities {
+	c := &Capabilities{granted: make(map[Capability]bool)}
+	c.Grant(capabilities...)
+	return c
+}
+
+// This function creates a set which grants every capability, with no restriction on the files which may be read
+func AllCapabilities() *Capabilities {
+	return NewCapabilities(allCapabilities...)
+}
+
+// This method grants capabilities. Granting fs-read this way lifts any restriction set by AllowRead.
+func (c *Capabilities) Grant(capabilities ...Capability) {
+	for _, capability := range capabilities {
+		c.granted[capability] = true
+		if capability == FS_READ {
+			c.readable = nil
+		}
+	}
+}
+
+// This method revokes capabilities
+func (c *Capabilities) Revoke(capabilities ...Capability) {
+	for _, capability := range capabilities {
+		delete(c.granted, capability)
+		if capability == FS_READ {
+			c.readable = nil
+		}
+	}
+}
+
+// This method grants fs-read for the given files and the files inside the given directories only
+func (c *Capabilities) AllowRead(paths ...string) {
+	c.granted[FS_READ] = true
+
+	for _, path := range paths {
+		if resolved, ok := resolvePath(path); ok {
+			c.readable = append(c.readable, resolved)
+		} else if absolute, err := filepath.Abs(path); err == nil {
+			c.readable = append(c.readable, absolute)
+		}
+	}
+}
+
+// This method reports whether a capability has been granted
+func (c *Capabilities) Has(capability Capability) bool {
+	return c.granted[capability]
+}
+
+// This method reports whether the file at path may be read
+func (c *Capabilities) CanRead(path string) bool {
+	_, ok := c.readablePath(path)
+	return ok
+}
+
+// This helper method reports whether the file at path may be read, returning the path to read it from.
+// Symbolic links are followed first, so that a link inside an allowed directory cannot lead outside it, and the file is
+// read from the resolved path so that the file which was checked is the file which is read.
+func (c *Capabilities) readablePath(path string) (string, bool) {
+	if !c.granted[FS_READ] {
+		return "", false
+	}
+
+	resolved, ok := resolvePath(path)
+	if !ok {
+		// A file which does not exist is checked by the directory it would be in
+		if directory, found := resolvePath(filepath.Dir(path)); found {
+			resolved, ok = filepath.Join(directory, filepath.Base(path)), true
+		}
+	}
+
+	if c.readable == nil {
+		if !ok {
+			return path, true
+		}
+		return resolved, true
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	for _, allowed := range c.readable {
+		relative, err := filepath.Rel(allowed, resolved)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return resolved, true
+		}
+	}
+
+	return "", false
+}
+
+// This helper function returns the absolute path of a file with every symbolic link along it followed
+func resolvePath(path string) (string, bool) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+
+	resolved, err := filepath.EvalSymlinks(absolute)
+	if err != nil {
+		return "", false
+	}
+
+	return resolved, true
+}
+
+// This helper method returns a PermissionDenied error if the programs run by the Evaluator lack a capability
+func (e *Evaluator) require(builtin string, capability Capability) *object.Error {
+	if !e.Capabilities.Has(capability) {
+		return permissionDenied("`%s` requires the %s capability", builtin, capability)
+	}
+	return nil
+}
+
+// This helper function creates the error reported when a builtin is used without the capability it needs
+func permissionDenied(format string, a ...interface{}) *object.Error {
+	err := newError("Permission Denied: "+format, a...)
+	err.Kind = object.PERMISSION_DENIED_ERROR
+	return err
+}
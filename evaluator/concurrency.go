@@ -0,0 +1,317 @@
+package evaluator
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/armansandhu/monkey_interpreter/ast"
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// the largest buffer a channel created by a program may have
+const maxChannelCapacity = 1 << 20
+
+// The built-in functions for working with tasks and channels. Those which can block receive the context of the
+// evaluation calling them, so that a cancelled evaluation stops waiting.
+var concurrencyBuiltins = map[string]*object.BuiltIn{
+	"channel": &object.BuiltIn{
+		Function: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+			}
+
+			capacity := int64(0)
+			if len(args) == 1 {
+				integer, ok := args[0].(*object.Integer)
+				if !ok {
+					return newError("Argument to `channel` is not supported! Instead received an %s!", args[0].Type())
+				}
+				capacity = integer.Value
+			}
+
+			if capacity < 0 || capacity > maxChannelCapacity {
+				return newError("Channel capacity must be between 0 and %d! Instead received %d!", maxChannelCapacity, capacity)
+			}
+
+			return object.NewChannel(int(capacity))
+		},
+	},
+	"send": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) (result object.Object) {
+			channel, err := channelArguments("send", args, 2)
+			if err != nil {
+				return err
+			}
+
+			defer recoverClosedChannel(&result)
+
+			select {
+			case channel.C <- args[1]:
+				return NULL
+			case <-ctx.Done():
+				return cancelled(ctx.Err())
+			}
+		},
+	},
+	"receive": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			channel, err := channelArguments("receive", args, 1)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case value, ok := <-channel.C:
+				if !ok {
+					return NULL
+				}
+				return value
+			case <-ctx.Done():
+				return cancelled(ctx.Err())
+			}
+		},
+	},
+	"close": &object.BuiltIn{
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+			}
+
+			switch arg := args[0].(type) {
+			case *object.Channel:
+				if !arg.Close() {
+					return newError("Channel Closed: the channel is already closed")
+				}
+			case *object.Generator:
+				arg.Close()
+			default:
+				return newError("Argument to `close` is not supported! Instead received an %s!", args[0].Type())
+			}
+			return NULL
+		},
+	},
+	"await": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+			}
+
+			task, ok := args[0].(*object.Task)
+			if !ok {
+				return newError("Argument to `await` is not supported! Instead received an %s!", args[0].Type())
+			}
+
+			select {
+			case <-task.Done():
+				if task.Result() == nil {
+					return NULL
+				}
+				return task.Result()
+			case <-ctx.Done():
+				return cancelled(ctx.Err())
+			}
+		},
+	},
+}
+
+func init() {
+	for name, builtin := range concurrencyBuiltins {
+		builtins[name] = builtin
+	}
+}
+
+// This helper function checks the arguments of a channel operation, returning the channel it operates on
+func channelArguments(name string, args []object.Object, expected int) (*object.Channel, *object.Error) {
+	if len(args) != expected {
+		return nil, newError("Incorrect number of arguments detected! Only needed %d but instead received %d!", expected, len(args))
+	}
+
+	channel, ok := args[0].(*object.Channel)
+	if !ok {
+		return nil, newError("Argument to `%s` is not supported! Instead received an %s!", name, args[0].Type())
+	}
+
+	return channel, nil
+}
+
+// This helper function turns the panic caused by sending on a closed channel into an error
+func recoverClosedChannel(result *object.Object) {
+	if r := recover(); r != nil {
+		*result = newError("Channel Closed: cannot send on a closed channel")
+	}
+}
+
+// This helper method waits on the cases of a select expression, turning a send on a closed channel into an error.
+// The cases which can proceed straight away are tried in an order drawn from e.Source, so that one of them is chosen
+// at random but a run with a SeededSource chooses the same one every time. Only if none can proceed does it fall back
+// to the default case, or wait for the first case to become ready.
+func (e *Evaluator) selectCases(cases []reflect.SelectCase) (chosen int, received reflect.Value, ok bool, err object.Object) {
+	defer recoverClosedChannel(&err)
+
+	order := []int{}
+	fallback := -1
+	for index, selectCase := range cases[:len(cases)-1] {
+		if selectCase.Dir == reflect.SelectDefault {
+			fallback = index
+			continue
+		}
+		order = append(order, index)
+	}
+
+	for index := len(order) - 1; index > 0; index-- {
+		swap := int(e.Source.Int63n(int64(index + 1)))
+		order[index], order[swap] = order[swap], order[index]
+	}
+
+	for _, index := range order {
+		poll := []reflect.SelectCase{cases[index], {Dir: reflect.SelectDefault}}
+		if polled, received, ok := reflect.Select(poll); polled == 0 {
+			return index, received, ok, nil
+		}
+	}
+
+	if fallback >= 0 {
+		return fallback, reflect.Value{}, false, nil
+	}
+
+	chosen, received, ok = reflect.Select(cases)
+	return chosen, received, ok, nil
+}
+
+// This method creates an Evaluator for running a spawned task on another goroutine. The task shares the
+// configuration, context and resource usage of e, and starts with a copy of its module cache.
+func (e *Evaluator) fork() *Evaluator {
+	modules := make(map[string]*object.Module, len(e.modules))
+	for path, module := range e.modules {
+		modules[path] = module
+	}
+
+	return &Evaluator{
+		SearchPath:   e.SearchPath,
+		Builtins:     e.Builtins,
+		Limits:       e.Limits,
+		Capabilities: e.Capabilities,
+		Source:       e.Source,
+		Output:       e.Output,
+		outputLock:   e.outputLock,
+		ctx:          e.ctx,
+		lifetime:     e.lifetime,
+		stop:         e.stop,
+		usage:        e.usage,
+		modules:      modules,
+		loading:      append([]string(nil), e.loading...),
+	}
+}
+
+// This method starts a task which calls a function on a new goroutine. The function and its arguments are
+// evaluated before the task starts, and the task's result can be collected with await.
+//
+// A task is not tied to the evaluation which spawned it, and keeps running after that evaluation returns so that a
+// later one can await it. It is cancelled when the context of the spawning evaluation is cancelled or the Evaluator
+// is closed. The task keeps counting against the Evaluator's usage, so its steps and allocations after ResetUsage are
+// charged to whichever evaluation is running then.
+func (e *Evaluator) evaluateSpawnExpression(se *ast.SpawnExpression, env *object.Environment) object.Object {
+	var function object.Object
+	var arguments []object.Object
+
+	if call, ok := se.Call.(*ast.CallExpression); ok {
+		function = e.Evaluate(call.Function, env)
+		if isError(function) {
+			return function
+		}
+
+		arguments = e.evaluateExpressions(call.Arguments, env)
+		if len(arguments) == 1 && isError(arguments[0]) {
+			return arguments[0]
+		}
+	} else {
+		function = e.Evaluate(se.Call, env)
+		if isError(function) {
+			return function
+		}
+	}
+
+	if !isCallable(function) {
+		return newError("Object is not a Function! Received a '%s'", function.Type())
+	}
+
+	task := object.NewTask()
+	child := e.fork()
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	stop := context.AfterFunc(e.lifetime, cancel)
+	child.ctx = ctx
+
+	go func() {
+		defer cancel()
+		defer stop()
+		defer func() {
+			if r := recover(); r != nil {
+				task.Complete(newError("Task Failed: %v", r))
+			}
+		}()
+
+		task.Complete(child.applyFunction(function, arguments))
+	}()
+
+	return e.account(task)
+}
+
+// This method waits until one of the cases of a select expression can proceed and evaluates its body.
+// If several can proceed one is chosen at random using e.Source, and the default case is chosen if none can proceed immediately.
+func (e *Evaluator) evaluateSelectExpression(se *ast.SelectExpression, env *object.Environment) object.Object {
+	cases := make([]reflect.SelectCase, 0, len(se.Cases)+1)
+
+	for _, selectCase := range se.Cases {
+		if selectCase.Operation == nil {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+			continue
+		}
+
+		name := selectCase.Operation.Function.String()
+		arguments := e.evaluateExpressions(selectCase.Operation.Arguments, env)
+		if len(arguments) == 1 && isError(arguments[0]) {
+			return arguments[0]
+		}
+
+		expected := 1
+		if name == "send" {
+			expected = 2
+		}
+
+		channel, err := channelArguments(name, arguments, expected)
+		if err != nil {
+			return err
+		}
+
+		if name == "send" {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(channel.C), Send: reflect.ValueOf(&arguments[1]).Elem()})
+		} else {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(channel.C)})
+		}
+	}
+
+	// The final case stops the select when the evaluation is cancelled
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(e.ctx.Done())})
+
+	chosen, received, ok, err := e.selectCases(cases)
+	if err != nil {
+		return err
+	}
+	if chosen == len(se.Cases) {
+		return cancelled(e.ctx.Err())
+	}
+
+	selected := se.Cases[chosen]
+	caseEnv := object.NewEnclosedEnvironment(env)
+
+	if selected.Binding != nil {
+		var value object.Object = NULL
+		if ok {
+			value = received.Interface().(object.Object)
+		}
+		caseEnv.Set(selected.Binding.Value, value)
+	}
+
+	return e.Evaluate(selected.Body, caseEnv)
+}
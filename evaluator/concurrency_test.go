@@ -0,0 +1,226 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+func TestSpawnAndAwait(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let task = spawn (x => x * 2)(21); await(task)`, 42},
+		{`let add = fn(a, b) { a + b }; await(spawn add(1, 2))`, 3},
+		{`let n = 10; await(spawn fn() { n + 1 })`, 11},
+		{`let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }
+let a = spawn fib(15)
+let b = spawn fib(16)
+await(a) + await(b)`, 1597},
+		{`let task = spawn fn() { return 5; 6 }; await(task) + await(task)`, 10},
+		{`await(spawn fn() { let x = 1 })`, nil},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestChannels(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let ch = channel(1); send(ch, 5); receive(ch)`, 5},
+		{`let ch = channel(); spawn send(ch, 7); receive(ch)`, 7},
+		{`let ch = channel(2); send(ch, 1); close(ch); receive(ch) + 1`, 2},
+		{`let ch = channel(); close(ch); receive(ch)`, nil},
+		{`let ch = channel()
+let producer = fn(n) { if (n > 0) { send(ch, n); producer(n - 1) } else { close(ch) } }
+let sum = fn(total) { let v = receive(ch); if (v == null) { total } else { sum(total + v) } }
+spawn producer(10)
+sum(0)`, 55},
+		{`let ch = channel(1); send(ch, 3); select { receive(ch) as v => v * 2, _ => 0 }`, 6},
+		{`let ch = channel(); select { receive(ch) as v => v, _ => 0 }`, 0},
+		{`let ch = channel(1); select { send(ch, 4) => receive(ch) }`, 4},
+		{`let ch = channel(); close(ch); select { receive(ch) as v => v }`, nil},
+		{`let a = channel(); let b = channel()
+spawn send(b, 9)
+select { receive(a) as v => v, receive(b) as v => v + 1 }`, 10},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestConcurrencyErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`spawn 5`, "Object is not a Function! Received a 'INTEGER'"},
+		{`spawn missing()`, "Identifier Not Found: missing"},
+		{`await(5)`, "Argument to `await` is not supported! Instead received an INTEGER!"},
+		{`await(spawn fn() { 1 + true })`, "Type Mismatch: INTEGER + BOOLEAN"},
+		{`channel(-1)`, "Channel capacity must be between 0 and 1048576! Instead received -1!"},
+		{`channel("a")`, "Argument to `channel` is not supported! Instead received an STRING!"},
+		{`send(1, 2)`, "Argument to `send` is not supported! Instead received an INTEGER!"},
+		{`receive(channel(), 1)`, "Incorrect number of arguments detected! Only needed 1 but instead received 2!"},
+		{`let ch = channel(1); close(ch); send(ch, 1)`, "Channel Closed: cannot send on a closed channel"},
+		{`let ch = channel(1); close(ch); select { send(ch, 1) => 1 }`, "Channel Closed: cannot send on a closed channel"},
+		{`let ch = channel(); close(ch); close(ch)`, "Channel Closed: the channel is already closed"},
+		{`select { receive(1) as v => v }`, "Argument to `receive` is not supported! Instead received an INTEGER!"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
+
+func TestSelectSeededChoice(t *testing.T) {
+	program := parser.New(lexer.New(`let a = channel(1); let b = channel(1); send(a, "a"); send(b, "b")
+select { receive(a) as v => v, receive(b) as v => v }`)).ParseProgram()
+
+	run := func(seed int64) string {
+		eval := New()
+		eval.Source = NewSeededSource(seed)
+		return eval.Evaluate(program, object.NewEnvironment()).Inspect()
+	}
+
+	chosen := make(map[string]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		first := run(seed)
+		for i := 0; i < 10; i++ {
+			if again := run(seed); again != first {
+				t.Fatalf("Select with seed %d chose differently between runs! Received %s and %s", seed, first, again)
+			}
+		}
+		chosen[first] = true
+	}
+
+	if !chosen["a"] || !chosen["b"] {
+		t.Errorf("Select should choose among ready cases at random! Only chose %v", chosen)
+	}
+}
+
+func TestSelectBodyPanics(t *testing.T) {
+	eval := New()
+	eval.Builtins.Register("boom", func(args ...object.Object) object.Object {
+		panic("boom")
+	})
+
+	program := parser.New(lexer.New(`await(spawn fn() { select { _ => boom() } }())`)).ParseProgram()
+	evaluated := eval.Evaluate(program, object.NewEnvironment())
+
+	errorObject, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("Object is not an Error! Instead received %T (%+v)", evaluated, evaluated)
+	}
+
+	if errorObject.Message != "Task Failed: boom" {
+		t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", "Task Failed: boom", errorObject.Message)
+	}
+}
+
+func TestBlockingOperationsCancelled(t *testing.T) {
+	inputs := []string{
+		`receive(channel())`,
+		`send(channel(), 1)`,
+		`await(spawn receive(channel()))`,
+		`select { receive(channel()) as v => v }`,
+	}
+
+	for _, input := range inputs {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		program := parser.New(lexer.New(input)).ParseProgram()
+		evaluated := New().EvaluateContext(ctx, program, object.NewEnvironment())
+		cancel()
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok || errorObject.Kind != object.CANCELLED_ERROR {
+			t.Errorf("Blocking operation %q was not cancelled! Instead received %+v", input, evaluated)
+		}
+	}
+}
+
+func TestEvaluatorCloseStopsTasks(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	env := object.NewEnvironment()
+	eval := New()
+	for i := 0; i < 10; i++ {
+		eval.Evaluate(parser.New(lexer.New(`spawn fn() { receive(channel()) }()`)).ParseProgram(), env)
+	}
+	program := parser.New(lexer.New(`let task = spawn fn() { receive(channel()) }()`)).ParseProgram()
+	eval.Evaluate(program, env)
+
+	eval.Close()
+
+	if !waitForGoroutines(before) {
+		t.Errorf("Closing the evaluator did not stop its tasks! %d goroutines were running before and %d after", before, runtime.NumGoroutine())
+	}
+
+	evaluated := eval.Evaluate(parser.New(lexer.New(`await(task)`)).ParseProgram(), env)
+	errorObject, ok := evaluated.(*object.Error)
+	if !ok || errorObject.Kind != object.CANCELLED_ERROR {
+		t.Errorf("A task stopped by Close should complete with a cancellation error! Instead received %+v", evaluated)
+	}
+}
+
+func TestConcurrentEvaluations(t *testing.T) {
+	program := parser.New(lexer.New(`let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }; fib(12)`)).ParseProgram()
+
+	var wg sync.WaitGroup
+	errors := make(chan string, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			evaluated := New().Evaluate(program, object.NewEnvironment())
+			if integer, ok := evaluated.(*object.Integer); !ok || integer.Value != 144 {
+				errors <- fmt.Sprintf("Incorrect result from a concurrent evaluation! Expected 144 but instead received %+v", evaluated)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errors)
+
+	for msg := range errors {
+		t.Error(msg)
+	}
+}
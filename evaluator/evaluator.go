@@ -1,7 +1,12 @@
 package evaluator
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/armansandhu/monkey_interpreter/ast"
 	"github.com/armansandhu/monkey_interpreter/object"
@@ -14,75 +19,235 @@ var (
 	NULL  = &object.Null{}
 )
 
+// Evaluator holds the state shared by everything evaluated during a run, such as the modules which have been loaded.
+// A single Evaluator can evaluate many programs, and the environments passed to it may be long lived, as in the REPL.
+// An Evaluator may only be used by one goroutine at a time, but separate Evaluators can run concurrently, and tasks
+// started with spawn run on their own copies which share its configuration and resource usage.
+type Evaluator struct {
+	// SearchPath lists the directories searched for imported modules which are not found relative to the importing file
+	SearchPath []string
+
+	// Builtins holds the built-in functions and methods available to programs run by this Evaluator
+	Builtins *Builtins
+
+	// Limits caps the resources used by programs run by this Evaluator
+	Limits Limits
+
+	// Capabilities holds the kinds of I/O which builtins may perform for programs run by this Evaluator
+	Capabilities *Capabilities
+
+	// Source supplies the time and random numbers used by builtins. Setting it to a SeededSource makes runs reproducible.
+	Source Source
+
+	// Output receives the text written by puts, print and printf. New sets it to os.Stdout.
+	Output io.Writer
+
+	ctx        context.Context
+	yielder    *object.Yielder
+	outputLock *sync.Mutex
+	lifetime   context.Context
+	stop       context.CancelFunc
+	usage      *Usage
+	depth      int
+	modules    map[string]*object.Module
+	loading    []string
+}
+
+// This function creates an Evaluator with an empty module cache and its own copy of the default builtins.
+// It is granted no capabilities, so builtins which perform I/O fail until they are granted.
+func New() *Evaluator {
+	lifetime, stop := context.WithCancel(context.Background())
+	e := &Evaluator{
+		Builtins:     DefaultBuiltins(),
+		Capabilities: NewCapabilities(),
+		Source:       systemSource{},
+		Output:       os.Stdout,
+		outputLock:   &sync.Mutex{},
+		ctx:          context.Background(),
+		lifetime:     lifetime,
+		stop:         stop,
+		usage:        &Usage{},
+		modules:      make(map[string]*object.Module),
+	}
+	return e
+}
+
+// This method evaluates a node, stopping with a cancellation error once ctx is cancelled or its deadline passes
+func (e *Evaluator) EvaluateContext(ctx context.Context, node ast.Node, env *object.Environment) object.Object {
+	defer e.useContext(ctx)()
+
+	if err := e.checkCancelled(); err != nil {
+		return err
+	}
+
+	return e.Evaluate(node, env)
+}
+
+// This helper method makes ctx the context checked during evaluation, returning a function which restores the previous one
+func (e *Evaluator) useContext(ctx context.Context) func() {
+	previous := e.ctx
+	e.ctx = ctx
+	return func() { e.ctx = previous }
+}
+
+// This helper method returns a cancellation error if the context of the evaluation is done.
+// It is checked wherever evaluation can repeat without bound, which is at every function call.
+func (e *Evaluator) checkCancelled() *object.Error {
+	if err := e.ctx.Err(); err != nil {
+		return cancelled(err)
+	}
+	return nil
+}
+
+// This helper function creates the error reported when an evaluation is stopped by its context
+func cancelled(err error) *object.Error {
+	return &object.Error{Message: "Cancelled: " + err.Error(), Kind: object.CANCELLED_ERROR}
+}
+
+// This function evaluates a node using a new Evaluator
 func Evaluate(node ast.Node, env *object.Environment) object.Object {
+	return New().Evaluate(node, env)
+}
+
+func (e *Evaluator) Evaluate(node ast.Node, env *object.Environment) object.Object {
+	if err := e.step(); err != nil {
+		return err
+	}
+
 	switch node := node.(type) {
 	case *ast.Program:
-		return evaluateProgram(node.Statements, env)
+		// Programs define their bindings in env, so they must run in an environment enclosing a frozen one instead
+		if env.Frozen() {
+			return newError("Frozen Environment: programs cannot be evaluated in a frozen environment")
+		}
+		return e.evaluateProgram(node.Statements, env)
 	case *ast.ExpressionStatement:
-		return Evaluate(node.Expression, env)
+		return e.Evaluate(node.Expression, env)
 	case *ast.IntegerLiteral:
 		return &object.Integer{Value: node.Value}
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.PrefixExpression:
-		right := Evaluate(node.Right, env)
+		right := e.Evaluate(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evaluatePrefixExpression(node.Operator, right)
+		return e.account(evaluatePrefixExpression(node.Operator, right))
 	case *ast.InfixExpression:
-		left := Evaluate(node.Left, env)
+		left := e.Evaluate(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		right := Evaluate(node.Right, env)
+		// The fallback of a null-coalescing expression is only evaluated when the left operand is null
+		if node.Operator == "??" {
+			if left != NULL {
+				return left
+			}
+			return e.Evaluate(node.Right, env)
+		}
+		right := e.Evaluate(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evaluateInfixExpression(left, node.Operator, right)
+		return e.applyInfixOperator(left, node.Operator, right)
 	case *ast.BlockStatement:
-		return evaluateBlockStatement(node, env)
+		return e.evaluateBlockStatement(node, env)
+	case *ast.NullLiteral:
+		return NULL
 	case *ast.IfExpression:
-		return evaluateIfExpression(node, env)
+		return e.evaluateIfExpression(node, env)
+	case *ast.ConditionalExpression:
+		return e.evaluateConditionalExpression(node, env)
+	case *ast.MatchExpression:
+		return e.evaluateMatchExpression(node, env)
 	case *ast.ReturnStatement:
-		value := Evaluate(node.ReturnValue, env)
+		if node.ReturnValue == nil {
+			return &object.ReturnValue{Value: NULL}
+		}
+		value := e.Evaluate(node.ReturnValue, env)
 		if isError(value) {
 			return value
 		}
 		return &object.ReturnValue{Value: value}
 	case *ast.LetStatement:
-		value := Evaluate(node.Value, env)
+		value := e.Evaluate(node.Value, env)
 		if isError(value) {
 			return value
 		}
+		if node.Pattern != nil {
+			if failed, failedValue := bindPattern(node.Pattern, value, env); failed != nil {
+				return newError("Pattern Mismatch: %s does not match %s", failed.String(), failedValue.Inspect())
+			}
+			return nil
+		}
 		env.Set(node.Name.Value, value)
 	case *ast.Identifier:
-		return evaluateIdentifier(node, env)
+		return e.evaluateIdentifier(node, env)
 	case *ast.FunctionLiteral:
+		// A function closes over the environment it is created in, so its body can use the variables which were in
+		// scope where it was defined even when it is called from somewhere they are not
 		parameters := node.Parameters
 		body := node.Body
-		return &object.Function{Parameters: parameters, Body: body}
+		return e.account(&object.Function{Parameters: parameters, Body: body, Env: env, Generator: node.Generator})
 	case *ast.CallExpression:
-		function := Evaluate(node.Function, env)
+		function := e.Evaluate(node.Function, env)
 		if isError(function) {
 			return function
 		}
-		arguments := evaluateExpressions(node.Arguments, env)
+		arguments := e.evaluateExpressions(node.Arguments, env)
 		if len(arguments) == 1 && isError(arguments[0]) {
 			return arguments[0]
 		}
-		return applyFunction(function, arguments)
+		return e.applyFunction(function, arguments)
 	case *ast.StringLiteral:
-		return &object.String{Value: node.Value}
+		return e.account(&object.String{Value: node.Value})
+	case *ast.StructStatement:
+		fields := []string{}
+		for _, field := range node.Fields {
+			fields = append(fields, field.Value)
+		}
+		env.Set(node.Name.Value, &object.StructDefinition{Name: node.Name.Value, Fields: fields})
+	case *ast.ClassStatement:
+		class := e.evaluateClassStatement(node, env)
+		if isError(class) {
+			return class
+		}
+		env.Set(node.Name.Value, class)
+	case *ast.AssignExpression:
+		return e.evaluateAssignExpression(node, env)
+	case *ast.StructLiteral:
+		return e.account(e.evaluateStructLiteral(node, env))
+	case *ast.SelectorExpression:
+		left := e.Evaluate(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		return e.evaluateSelectorExpression(left, node.Field.Value)
+	case *ast.ImportStatement:
+		return e.evaluateImportStatement(node, env)
+	case *ast.ExportStatement:
+		return e.Evaluate(node.Statement, env)
+	case *ast.YieldExpression:
+		return e.evaluateYieldExpression(node, env)
+	case *ast.SpawnExpression:
+		return e.evaluateSpawnExpression(node, env)
+	case *ast.SelectExpression:
+		return e.evaluateSelectExpression(node, env)
+	case *ast.TupleLiteral:
+		elements := e.evaluateExpressions(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return e.account(&object.Tuple{Elements: elements})
 	}
 	return nil
 }
 
-func evaluateProgram(statements []ast.Statement, env *object.Environment) object.Object {
+func (e *Evaluator) evaluateProgram(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range statements {
-		result = Evaluate(statement, env)
+		result = e.Evaluate(statement, env)
 
 		switch result := result.(type) {
 		case *object.ReturnValue:
@@ -136,7 +301,14 @@ func evaluateMinusPrefixOperatorExpression(right object.Object) object.Object {
 }
 
 func evaluateInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	// Objects supplied by embedding code can implement their own operators
+	if result := evaluateOverloadedInfixExpression(left, operator, right); result != nil {
+		return result
+	}
+
 	switch {
+	case operator == ">>" && isCallable(left) && isCallable(right):
+		return &object.Composition{First: left, Second: right}
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evaluateIntegerInfixExpression(left, operator, right)
 	case operator == "==":
@@ -152,6 +324,45 @@ func evaluateInfixExpression(left object.Object, operator string, right object.O
 	}
 }
 
+// This function consults the operator interfaces of package object. It returns nil if neither operand handles the operator.
+func evaluateOverloadedInfixExpression(left object.Object, operator string, right object.Object) object.Object {
+	if operand, ok := left.(object.BinaryOperand); ok {
+		if result := operand.Operate(operator, right); result != nil {
+			return result
+		}
+	}
+
+	if operand, ok := right.(object.ReflectedBinaryOperand); ok {
+		if result := operand.OperateReflected(operator, left); result != nil {
+			return result
+		}
+	}
+
+	if operator == "==" || operator == "!=" {
+		if equaler, ok := left.(object.Equaler); ok {
+			return nativeBoolToBooleanObject(equaler.Equals(right) == (operator == "=="))
+		}
+		if equaler, ok := right.(object.Equaler); ok {
+			return nativeBoolToBooleanObject(equaler.Equals(left) == (operator == "=="))
+		}
+	}
+
+	if operator == "+" {
+		if str, ok := left.(*object.String); ok {
+			if stringer, ok := right.(object.Stringer); ok {
+				return &object.String{Value: str.Value + stringer.String()}
+			}
+		}
+		if str, ok := right.(*object.String); ok {
+			if stringer, ok := left.(object.Stringer); ok {
+				return &object.String{Value: stringer.String() + str.Value}
+			}
+		}
+	}
+
+	return nil
+}
+
 func evaluateIntegerInfixExpression(left object.Object, operator string, right object.Object) object.Object {
 	leftValue := left.(*object.Integer).Value
 	rightValue := right.(*object.Integer).Value
@@ -164,6 +375,9 @@ func evaluateIntegerInfixExpression(left object.Object, operator string, right o
 	case "*":
 		return &object.Integer{Value: leftValue * rightValue}
 	case "/":
+		if rightValue == 0 {
+			return domainError("cannot divide %d by zero!", leftValue)
+		}
 		return &object.Integer{Value: leftValue / rightValue}
 	case ">":
 		return nativeBoolToBooleanObject(leftValue > rightValue)
@@ -178,33 +392,340 @@ func evaluateIntegerInfixExpression(left object.Object, operator string, right o
 	}
 }
 
+// This function evaluates + and the comparisons < and >, which order strings byte by byte like Go's string comparison
 func evaluateStringInfixExpression(left object.Object, operator string, right object.Object) object.Object {
-	if operator != "+" {
-		return newError("Unknown Operator: %s %s %s", left.Type(), operator, right.Type())
-	}
-
 	leftValue := left.(*object.String).Value
 	rightValue := right.(*object.String).Value
 
-	return &object.String{Value: leftValue + rightValue}
+	switch operator {
+	case "+":
+		return &object.String{Value: leftValue + rightValue}
+	case "<":
+		return nativeBoolToBooleanObject(leftValue < rightValue)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue > rightValue)
+	default:
+		return newError("Unknown Operator: %s %s %s", left.Type(), operator, right.Type())
+	}
 }
 
-func evaluateIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
-	condition := Evaluate(ie.Condition, env)
+func (e *Evaluator) evaluateIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := e.Evaluate(ie.Condition, env)
 
 	if isError(condition) {
 		return condition
 	}
 
 	if isTruthy(condition) {
-		return Evaluate(ie.Consequence, env)
+		return e.Evaluate(ie.Consequence, env)
 	} else if ie.Alternative != nil {
-		return Evaluate(ie.Alternative, env)
+		return e.Evaluate(ie.Alternative, env)
 	} else {
 		return NULL
 	}
 }
 
+func (e *Evaluator) evaluateConditionalExpression(ce *ast.ConditionalExpression, env *object.Environment) object.Object {
+	condition := e.Evaluate(ce.Condition, env)
+
+	if isError(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return e.Evaluate(ce.Consequence, env)
+	}
+	return e.Evaluate(ce.Alternative, env)
+}
+
+func (e *Evaluator) evaluateMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := e.Evaluate(me.Subject, env)
+
+	if isError(subject) {
+		return subject
+	}
+
+	// Arms are tried in order and the first arm with a matching pattern and a truthy guard is evaluated
+	for _, arm := range me.Arms {
+		armEnv := object.NewEnclosedEnvironment(env)
+		if failed, _ := matchPattern(arm.Pattern, subject, armEnv); failed != nil {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := e.Evaluate(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		return e.Evaluate(arm.Body, armEnv)
+	}
+
+	return newError("No Matching Arm: %s", subject.Inspect())
+}
+
+// This helper function matches a value against a pattern, binding any identifiers within the pattern into env.
+// It returns a nil pattern on success, otherwise the innermost pattern that failed to match along with the value it was matched against.
+func matchPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (ast.Pattern, object.Object) {
+	switch pattern := pattern.(type) {
+	case *ast.WildcardPattern:
+		return nil, nil
+	case *ast.IdentifierPattern:
+		env.Set(pattern.Name.Value, value)
+		return nil, nil
+	case *ast.LiteralPattern:
+		if !matchLiteral(pattern.Value, value) {
+			return pattern, value
+		}
+		return nil, nil
+	case *ast.TuplePattern:
+		tuple, ok := value.(*object.Tuple)
+		if !ok || len(tuple.Elements) != len(pattern.Elements) {
+			return pattern, value
+		}
+
+		for index, element := range pattern.Elements {
+			if failed, failedValue := matchPattern(element, tuple.Elements[index], env); failed != nil {
+				return failed, failedValue
+			}
+		}
+		return nil, nil
+	case *ast.AlternativePattern:
+		for _, alternative := range pattern.Alternatives {
+			if failed, _ := bindPattern(alternative, value, env); failed == nil {
+				return nil, nil
+			}
+		}
+		return pattern, value
+	default:
+		return pattern, value
+	}
+}
+
+// This helper function matches a value against a pattern like matchPattern, but only binds the identifiers within the
+// pattern into env once the whole pattern has matched, so that a pattern which fails part way through binds nothing
+func bindPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (ast.Pattern, object.Object) {
+	scratch := object.NewEnvironment()
+	if failed, failedValue := matchPattern(pattern, value, scratch); failed != nil {
+		return failed, failedValue
+	}
+
+	for _, name := range scratch.Names() {
+		binding, _ := scratch.Get(name)
+		env.Set(name, binding)
+	}
+	return nil, nil
+}
+
+func matchLiteral(literal ast.Expression, value object.Object) bool {
+	switch literal := literal.(type) {
+	case *ast.IntegerLiteral:
+		integer, ok := value.(*object.Integer)
+		return ok && integer.Value == literal.Value
+	case *ast.PrefixExpression:
+		right, ok := literal.Right.(*ast.IntegerLiteral)
+		integer, isInteger := value.(*object.Integer)
+		return ok && isInteger && integer.Value == -right.Value
+	case *ast.StringLiteral:
+		str, ok := value.(*object.String)
+		return ok && str.Value == literal.Value
+	case *ast.Boolean:
+		return value == nativeBoolToBooleanObject(literal.Value)
+	case *ast.NullLiteral:
+		return value == NULL
+	default:
+		return false
+	}
+}
+
+func (e *Evaluator) evaluateStructLiteral(sl *ast.StructLiteral, env *object.Environment) object.Object {
+	name := e.Evaluate(sl.Name, env)
+	if isError(name) {
+		return name
+	}
+
+	definition, ok := name.(*object.StructDefinition)
+	if !ok {
+		return newError("Not a Struct: %s", sl.Name.String())
+	}
+
+	instance := &object.Struct{Definition: definition, Fields: make(map[string]object.Object)}
+
+	// Fields which are not set explicitly are copied from the base struct
+	if sl.Base != nil {
+		base := e.Evaluate(sl.Base, env)
+		if isError(base) {
+			return base
+		}
+
+		baseStruct, ok := base.(*object.Struct)
+		if !ok || baseStruct.Definition != definition {
+			return newError("Type Mismatch: cannot copy fields of %s into %s", base.Inspect(), definition.Name)
+		}
+
+		for field, value := range baseStruct.Fields {
+			instance.Fields[field] = value
+		}
+	}
+
+	assigned := make(map[string]bool)
+
+	for _, field := range sl.Fields {
+		if !definition.HasField(field.Name.Value) {
+			return newError("Unknown Field: %s has no field '%s'", definition.Name, field.Name.Value)
+		}
+
+		if assigned[field.Name.Value] {
+			return newError("Duplicate Field: '%s' is set more than once", field.Name.Value)
+		}
+		assigned[field.Name.Value] = true
+
+		value := e.Evaluate(field.Value, env)
+		if isError(value) {
+			return value
+		}
+		instance.Fields[field.Name.Value] = value
+	}
+
+	for _, field := range definition.Fields {
+		if _, ok := instance.Fields[field]; !ok {
+			return newError("Missing Field: %s requires field '%s'", definition.Name, field)
+		}
+	}
+
+	return instance
+}
+
+// This function evaluates dot syntax. A struct field takes priority, otherwise the name is looked up in the method table
+// of the value's type and returned as a method bound to the value.
+func (e *Evaluator) evaluateSelectorExpression(left object.Object, field string) object.Object {
+	switch left := left.(type) {
+	case *object.Module:
+		if value, ok := left.Exports[field]; ok {
+			return value
+		}
+		return newError("Unknown Export: %s has no export '%s'", left.Name, field)
+	case *object.Struct:
+		if value, ok := left.Fields[field]; ok {
+			return value
+		}
+	case *object.Instance:
+		if value, ok := left.GetField(field); ok {
+			return value
+		}
+		if method, class := left.Class.FindMethod(field); method != nil {
+			return &object.BoundMethod{Receiver: left, Name: field, Method: method, Class: class}
+		}
+		return newError("Unknown Field: %s has no field or method '%s'", left.Class.Name, field)
+	case *object.Super:
+		if method, class := left.Class.FindMethod(field); method != nil {
+			return &object.BoundMethod{Receiver: left.Receiver, Name: field, Method: method, Class: class}
+		}
+		return newError("Unknown Method: %s has no method '%s'", left.Class.Name, field)
+	}
+
+	if method, ok := e.Builtins.LookupMethod(left.Type(), field); ok {
+		return &object.BoundMethod{Receiver: left, Name: field, Method: method}
+	}
+
+	if instance, ok := left.(*object.Struct); ok {
+		return newError("Unknown Field: %s has no field '%s'", instance.Definition.Name, field)
+	}
+
+	return newError("Unknown Method: %s has no method '%s'", left.Type(), field)
+}
+
+func (e *Evaluator) evaluateClassStatement(cs *ast.ClassStatement, env *object.Environment) object.Object {
+	class := &object.Class{Name: cs.Name.Value, Methods: make(map[string]*object.Function)}
+
+	if cs.Parent != nil {
+		parent := e.Evaluate(cs.Parent, env)
+		if isError(parent) {
+			return parent
+		}
+
+		parentClass, ok := parent.(*object.Class)
+		if !ok {
+			return newError("Not a Class: %s", cs.Parent.String())
+		}
+		class.Parent = parentClass
+	}
+
+	for _, method := range cs.Methods {
+		class.Methods[method.Name.Value] = &object.Function{Parameters: method.Function.Parameters, Body: method.Function.Body, Env: env, Generator: method.Function.Generator}
+	}
+
+	return class
+}
+
+// This function evaluates an assignment. Variables must already be bound, and only the fields of class instances can be assigned;
+// struct fields are immutable and are updated by copying instead. Compound operators such as += apply their operator to the current value.
+func (e *Evaluator) evaluateAssignExpression(ae *ast.AssignExpression, env *object.Environment) object.Object {
+	value := e.Evaluate(ae.Value, env)
+	if isError(value) {
+		return value
+	}
+
+	operator := strings.TrimSuffix(ae.Operator, "=")
+
+	switch target := ae.Target.(type) {
+	case *ast.Identifier:
+		if operator != "" {
+			current := e.evaluateIdentifier(target, env)
+			if isError(current) {
+				return current
+			}
+
+			value = e.applyInfixOperator(current, operator, value)
+			if isError(value) {
+				return value
+			}
+		}
+
+		if env.FrozenBinding(target.Value) {
+			return newError("Frozen Binding: cannot assign to %s, it is defined in a frozen environment", target.Value)
+		}
+		if !env.Assign(target.Value, value) {
+			return newError("Identifier Not Found: " + target.Value)
+		}
+		return value
+	case *ast.SelectorExpression:
+		left := e.Evaluate(target.Left, env)
+		if isError(left) {
+			return left
+		}
+
+		instance, ok := left.(*object.Instance)
+		if !ok {
+			if structure, ok := left.(*object.Struct); ok {
+				return newError("Immutable Field: cannot assign to %s.%s, structs are updated by copying", structure.Definition.Name, target.Field.Value)
+			}
+			return newError("Cannot Assign Field: %s has no assignable fields", left.Type())
+		}
+
+		if operator != "" {
+			current, ok := instance.GetField(target.Field.Value)
+			if !ok {
+				return newError("Unknown Field: %s has no field '%s'", instance.Class.Name, target.Field.Value)
+			}
+
+			value = e.applyInfixOperator(current, operator, value)
+			if isError(value) {
+				return value
+			}
+		}
+
+		instance.SetField(target.Field.Value, value)
+		return value
+	default:
+		return newError("Invalid Assignment Target: %s", ae.Target.String())
+	}
+}
+
 func isTruthy(obj object.Object) bool {
 	switch obj {
 	case NULL:
@@ -218,11 +739,11 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
-func evaluateBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+func (e *Evaluator) evaluateBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range block.Statements {
-		result = Evaluate(statement, env)
+		result = e.Evaluate(statement, env)
 
 		if result != nil {
 			returnType := result.Type()
@@ -246,23 +767,23 @@ func isError(obj object.Object) bool {
 	return false
 }
 
-func evaluateIdentifier(i *ast.Identifier, env *object.Environment) object.Object {
+func (e *Evaluator) evaluateIdentifier(i *ast.Identifier, env *object.Environment) object.Object {
 	if value, ok := env.Get(i.Value); ok {
 		return value
 	}
 
-	if builtin, ok := builtins[i.Value]; ok {
+	if builtin, ok := e.Builtins.Lookup(i.Value); ok {
 		return builtin
 	}
 
 	return newError("Identifier Not Found: " + i.Value)
 }
 
-func evaluateExpressions(expressions []ast.Expression, env *object.Environment) []object.Object {
+func (e *Evaluator) evaluateExpressions(expressions []ast.Expression, env *object.Environment) []object.Object {
 	var result []object.Object
 
-	for _, e := range expressions {
-		evaluated := Evaluate(e, env)
+	for _, expression := range expressions {
+		evaluated := e.Evaluate(expression, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
 		}
@@ -272,19 +793,123 @@ func evaluateExpressions(expressions []ast.Expression, env *object.Environment)
 	return result
 }
 
-func applyFunction(function object.Object, arguments []object.Object) object.Object {
+// This method calls any callable Monkey value with the given arguments, so that host code can invoke Monkey callbacks
+func (e *Evaluator) Call(function object.Object, arguments ...object.Object) object.Object {
+	return e.applyFunction(function, arguments)
+}
+
+// This method calls a Monkey value like Call, stopping with a cancellation error once ctx is cancelled or its deadline passes
+func (e *Evaluator) CallContext(ctx context.Context, function object.Object, arguments ...object.Object) object.Object {
+	defer e.useContext(ctx)()
+	return e.applyFunction(function, arguments)
+}
+
+// This method calls a callable value. A Monkey function must receive exactly as many arguments as it has parameters:
+// extra arguments are an error rather than being ignored, and missing ones are an error rather than a crash.
+func (e *Evaluator) applyFunction(function object.Object, arguments []object.Object) object.Object {
+	if err := e.checkCancelled(); err != nil {
+		return err
+	}
+
+	if err := e.enterCall(); err != nil {
+		return err
+	}
+	defer func() { e.depth-- }()
+
 	switch fn := function.(type) {
 	case *object.Function:
+		if len(arguments) != len(fn.Parameters) {
+			return newError("Incorrect number of arguments detected! Needed %d but instead received %d!", len(fn.Parameters), len(arguments))
+		}
+		if err := e.allocate(environmentSize + int64(len(arguments))*referenceSize); err != nil {
+			return err
+		}
 		extendedEnv := extendFunctionEnv(fn, arguments)
-		evaluated := Evaluate(fn.Body, extendedEnv)
-		return unWrapReturnValue(evaluated)
+		return e.evaluateFunctionBody(fn, extendedEnv)
 	case *object.BuiltIn:
-		return fn.Function(arguments...)
+		if fn.ContextFunction != nil {
+			return e.account(fn.ContextFunction(e.builtinContext(), arguments...))
+		}
+		return e.account(fn.Function(arguments...))
+	case *object.BoundMethod:
+		arguments = append([]object.Object{fn.Receiver}, arguments...)
+		if method, ok := fn.Method.(*object.Function); ok && fn.Class != nil {
+			return e.applyMethod(method, fn.Class, arguments)
+		}
+		return e.applyFunction(fn.Method, arguments)
+	case *object.Class:
+		return e.account(e.instantiateClass(fn, arguments))
+	case *object.Composition:
+		result := e.applyFunction(fn.First, arguments)
+		if isError(result) {
+			return result
+		}
+		return e.applyFunction(fn.Second, []object.Object{result})
 	default:
 		return newError("Object is not a Function! Received a '%s'", function.Type())
 	}
 }
 
+// This function calls a method of a user-defined class. Inside the method 'super' refers to the parent of the defining class,
+// bound to the same instance.
+func (e *Evaluator) applyMethod(method *object.Function, class *object.Class, arguments []object.Object) object.Object {
+	if len(arguments) != len(method.Parameters) {
+		return newError("Incorrect number of arguments detected! Needed %d but instead received %d!", len(method.Parameters), len(arguments))
+	}
+
+	if err := e.allocate(environmentSize + int64(len(arguments))*referenceSize); err != nil {
+		return err
+	}
+
+	extendedEnv := extendFunctionEnv(method, arguments)
+
+	if instance, ok := arguments[0].(*object.Instance); ok && class.Parent != nil {
+		extendedEnv.Set("super", &object.Super{Class: class.Parent, Receiver: instance})
+	}
+
+	return e.evaluateFunctionBody(method, extendedEnv)
+}
+
+// This method evaluates the body of a function in the environment holding its arguments.
+// The body of a generator function is not evaluated yet, instead a generator which evaluates it lazily is returned.
+func (e *Evaluator) evaluateFunctionBody(fn *object.Function, env *object.Environment) object.Object {
+	if fn.Generator {
+		return e.account(e.newGenerator(fn.Body, env))
+	}
+
+	evaluated := e.Evaluate(fn.Body, env)
+	return unWrapReturnValue(evaluated)
+}
+
+// This function creates a new instance of a class, passing the arguments on to its 'init' method if it has one
+func (e *Evaluator) instantiateClass(class *object.Class, arguments []object.Object) object.Object {
+	instance := object.NewInstance(class)
+
+	init, definingClass := class.FindMethod("init")
+	if init == nil {
+		if len(arguments) != 0 {
+			return newError("Incorrect number of arguments detected! Needed 0 but instead received %d!", len(arguments))
+		}
+		return instance
+	}
+
+	result := e.applyMethod(init, definingClass, append([]object.Object{instance}, arguments...))
+	if isError(result) {
+		return result
+	}
+
+	return instance
+}
+
+func isCallable(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Function, *object.BuiltIn, *object.BoundMethod, *object.Composition, *object.Class:
+		return true
+	default:
+		return false
+	}
+}
+
 func extendFunctionEnv(function *object.Function, arguments []object.Object) *object.Environment {
 	environment := object.NewEnclosedEnvironment(function.Env)
 
@@ -1,7 +1,11 @@
 package evaluator
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/armansandhu/monkey_interpreter/lexer"
 	"github.com/armansandhu/monkey_interpreter/object"
@@ -99,6 +103,9 @@ func TestIfElseExpression(t *testing.T) {
 		{"if (1 > 2) { 10 }", nil},
 		{"if (1 > 2) { 10 } else { 20 }", 20},
 		{"if (1 < 2) { 10 } else { 20 }", 10},
+		{"if (1 > 2) { 10 } else if (2 > 1) { 20 } else { 30 }", 20},
+		{"if (1 > 2) { 10 } else if (2 > 3) { 20 } else { 30 }", 30},
+		{"if (1 > 2) { 10 } else if (2 > 3) { 20 }", nil},
 	}
 
 	for _, tt := range tests {
@@ -112,6 +119,121 @@ func TestIfElseExpression(t *testing.T) {
 	}
 }
 
+func TestConditionalExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"true ? 1 : 2", 1},
+		{"false ? 1 : 2", 2},
+		{"null ? 1 : 2", 2},
+		{"1 < 2 ? 10 : 20", 10},
+		{"false ? 1 : true ? 2 : 3", 2},
+		{"false ? 1 : false ? 2 : null", nil},
+		{"null ?? 5", 5},
+		{"7 ?? 5", 7},
+		{"null ?? null ?? 9", 9},
+		{"if (false) { 1 } ?? 4", 4},
+		{"let x = null; x ?? 3", 3},
+		{"1 ?? foobar", 1},
+		{"null", nil},
+		{"null == null", true},
+		{"1 != null", true},
+		{"match (null) { null => 1, _ => 2 }", 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`match (1) { 1 => "one", 2 => "two", _ => "many" }`, "one"},
+		{`match (2) { 1 => "one", 2 => "two", _ => "many" }`, "two"},
+		{`match (7) { 1 => "one", 2 => "two", _ => "many" }`, "many"},
+		{`match ("y") { "x" | "y" => "letter", _ => "other" }`, "letter"},
+		{`match (-1) { -1 => "negative", _ => "other" }`, "negative"},
+		{`match (true) { false => 0, true => 1 }`, 1},
+		{`match (15) { n if n > 10 => n * 2, n => n }`, 30},
+		{`match (5) { n if n > 10 => n * 2, n => n }`, 5},
+		{`let x = 3; match (x + 1) { 4 => { let y = x * 2; y } }`, 6},
+		{`match ("1") { 1 => "integer", _ => "string" }`, "string"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("Object is not of type String! Instead received '%T' (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if str.Value != expected {
+				t.Errorf("String has the incorrect value! Expected %q but instead received %q", expected, str.Value)
+			}
+		}
+	}
+}
+
+func TestFailedPatternsBindNothing(t *testing.T) {
+	testIntegerObject(t, testEvaluate(`let a = 1; let t = (5, 3); match (t) { (a, 2) | (_, 3) => a }`), 1)
+
+	env := object.NewEnvironment()
+	New().Evaluate(parser.New(lexer.New(`let (b, 2) = (5, 3)`)).ParseProgram(), env)
+	if value, ok := env.Get("b"); ok {
+		t.Errorf("A let pattern which failed to match should not bind anything! Instead b is %s", value.Inspect())
+	}
+}
+
+func TestTuples(t *testing.T) {
+	evaluated := testEvaluate(`(1, "two", (3, true))`)
+
+	tuple, ok := evaluated.(*object.Tuple)
+	if !ok {
+		t.Fatalf("Object is not of type Tuple! Instead received '%T' (%+v)", evaluated, evaluated)
+	}
+
+	if tuple.Inspect() != "(1, two, (3, true))" {
+		t.Errorf("Tuple has the incorrect value! Expected %q but instead received %q", "(1, two, (3, true))", tuple.Inspect())
+	}
+
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let (a, b) = (1, 2); a + b;", 3},
+		{"let (a, (b, c)) = (1, (2, 3)); a * b * c;", 6},
+		{"let (_, b) = (1, 2); b;", 2},
+		{"let pair = (4, 5); let (x, y) = pair; y - x;", 1},
+		{"match ((1, 2)) { (0, y) => y, (1, y) => y * 10 }", 20},
+		{"match ((2, (3, 4))) { (1, _) => 0, (2, (x, y)) => x + y }", 7},
+		{"match ((5, 0)) { (1 | 5, n) => n + 1, _ => 0 }", 1},
+		{"match ((5, 9)) { (x, y) if x > y => x, (x, y) => y }", 9},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
 func TestReturnStatements(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -188,6 +310,138 @@ func TestErrorHandling(t *testing.T) {
 			`"Hello" - "World"`,
 			"Unknown Operator: STRING - STRING",
 		},
+		{
+			`match (3) { 1 => "one", 2 => "two" }`,
+			"No Matching Arm: 3",
+		},
+		{
+			`match (3) { n if n > 5 => n }`,
+			"No Matching Arm: 3",
+		},
+		{
+			"1 >> 2",
+			"Unknown Operator: INTEGER >> INTEGER",
+		},
+		{
+			"let f = fn(x) { x } >> len; f(1);",
+			"Argument to `len` is not supported! Instead received an INTEGER!",
+		},
+		{
+			"5 |> 3",
+			"Object is not a Function! Received a 'INTEGER'",
+		},
+		{
+			"struct Point { x, y }; Point { x: 1, y: 2, z: 3 }",
+			"Unknown Field: Point has no field 'z'",
+		},
+		{
+			"struct Point { x, y }; Point { x: 1 }",
+			"Missing Field: Point requires field 'y'",
+		},
+		{
+			"struct Point { x, y }; Point { x: 1, x: 2, y: 3 }",
+			"Duplicate Field: 'x' is set more than once",
+		},
+		{
+			"struct Point { x, y }; let p = Point { x: 1, y: 2 }; p.z",
+			"Unknown Field: Point has no field 'z'",
+		},
+		{
+			"let p = 5; p.x",
+			"Unknown Method: INTEGER has no method 'x'",
+		},
+		{
+			`"abc".reverse()`,
+			"Unknown Method: STRING has no method 'reverse'",
+		},
+		{
+			`"abc".upper(1)`,
+			"Incorrect number of arguments to `upper` detected! Only needed 0 but instead received 1!",
+		},
+		{
+			`"a,b".split(1)`,
+			"Argument to `split` is not supported! Instead received an INTEGER!",
+		},
+		{
+			"let p = 5; p { x: 1 }",
+			"Not a Struct: p",
+		},
+		{
+			"struct Point { x, y }; struct Size { x, y }; let s = Size { x: 1, y: 2 }; Point { x: 1, ..s }",
+			"Type Mismatch: cannot copy fields of Size{x: 1, y: 2} into Point",
+		},
+		{
+			"class A { init(self) { self.x = 1 } }; A().y",
+			"Unknown Field: A has no field or method 'y'",
+		},
+		{
+			"class A {}; A(1)",
+			"Incorrect number of arguments detected! Needed 0 but instead received 1!",
+		},
+		{
+			"class A { init(self, x) { self.x = x } }; A()",
+			"Incorrect number of arguments detected! Needed 2 but instead received 1!",
+		},
+		{
+			"class A { go(self) { super.go() } }; class B(A) { go(self) { super.go() } }; B().go()",
+			"Identifier Not Found: super",
+		},
+		{
+			"class A {}; class B(A) { go(self) { super.missing() } }; B().go()",
+			"Unknown Method: A has no method 'missing'",
+		},
+		{
+			"let A = 5; class B(A) {}",
+			"Not a Class: A",
+		},
+		{
+			"let f = fn(x) { x }; f(1, 2)",
+			"Incorrect number of arguments detected! Needed 1 but instead received 2!",
+		},
+		{
+			"let f = fn(x, y) { x }; f(1)",
+			"Incorrect number of arguments detected! Needed 2 but instead received 1!",
+		},
+		{
+			"y = 5",
+			"Identifier Not Found: y",
+		},
+		{
+			"let x = 1; x += true",
+			"Type Mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"struct Point { x, y }; let p = Point { x: 1, y: 2 }; p.x = 5",
+			"Immutable Field: cannot assign to Point.x, structs are updated by copying",
+		},
+		{
+			"let s = \"abc\"; s.x = 5",
+			"Cannot Assign Field: STRING has no assignable fields",
+		},
+		{
+			"class A {}; let a = A(); a.n += 1",
+			"Unknown Field: A has no field 'n'",
+		},
+		{
+			"let (a, b) = 5;",
+			"Pattern Mismatch: (a, b) does not match 5",
+		},
+		{
+			"let (a, b) = (1, 2, 3);",
+			"Pattern Mismatch: (a, b) does not match (1, 2, 3)",
+		},
+		{
+			"let (a, (b, 1)) = (1, (2, 3));",
+			"Pattern Mismatch: 1 does not match 3",
+		},
+		{
+			"match ((1, 2)) { (2, _) => 1 }",
+			"No Matching Arm: (1, 2)",
+		},
+		{
+			`match (3) { n if n + true => n }`,
+			"Type Mismatch: INTEGER + BOOLEAN",
+		},
 	}
 
 	for _, tt := range tests {
@@ -263,6 +517,607 @@ func TestFunctionApplication(t *testing.T) {
 	}
 }
 
+func TestClosures(t *testing.T) {
+	input := `
+	let newAdder = fn(x) {
+		fn(y) { x + y };
+	};
+
+	let addTwo = newAdder(2);
+	addTwo(2);`
+
+	testIntegerObject(t, testEvaluate(input), 4)
+}
+
+func TestClosuresCaptureDefiningEnvironment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let make = fn() { let hidden = 7; fn() { hidden } }; let get = make(); get()", 7},
+		{"let hidden = 1; let make = fn() { let hidden = 2; fn() { hidden } }; make()()", 2},
+		{"let outer = fn(a) { fn(b) { fn(c) { a + b + c } } }; outer(1)(2)(3)", 6},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestPipelineAndComposition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let double = fn(x) { x * 2 }; 5 |> double;", 10},
+		{"let sub = fn(a, b) { a - b }; 10 |> sub(3);", 7},
+		{"let inc = fn(x) { x + 1 }; let mul = fn(a, b) { a * b }; 2 |> inc |> mul(5) |> inc;", 16},
+		{`"four" |> len`, 4},
+		{"let inc = fn(x) { x + 1 }; let double = fn(x) { x * 2 }; (inc >> double)(3);", 8},
+		{"let inc = fn(x) { x + 1 }; let double = fn(x) { x * 2 }; (double >> inc)(3);", 7},
+		{"let add = fn(a, b) { a + b }; let double = fn(x) { x * 2 }; (add >> double)(1, 2);", 6},
+		{"let inc = fn(x) { x + 1 }; let f = inc >> inc >> inc; 0 |> f;", 3},
+		{"let adder = fn(n) { fn(x) { x + n } }; 1 |> adder(2) >> adder(3);", 6},
+		{`let double = fn(x) { x * 2 }; ("abc" |> len >> double);`, 6},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let double = x => x * 2; double(5);", 10},
+		{"let add = (a, b) => a + b; add(2, 3);", 5},
+		{"let five = () => 5; five();", 5},
+		{"let apply = fn(f, x) { f(x) }; apply(x => x + 1, 1);", 2},
+		{"let n = 10; let addN = x => x + n; addN(5);", 15},
+		{"let add = x => y => x + y; add(3)(4);", 7},
+		{"let f = x => { let y = x * 3; y + 1 }; f(2);", 7},
+		{"4 |> (x => x * x)", 16},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestOptionalSemicolons(t *testing.T) {
+	input := `
+	let fib = fn(n) {
+		if (n < 2) {
+			return n
+		}
+		fib(n - 1) +
+			fib(n - 2)
+	}
+
+	let total = fib(10)
+	total`
+
+	testIntegerObject(t, testEvaluate(input), 55)
+	testNullObject(t, testEvaluate("let f = fn() {\n\treturn\n\t5\n}\nf()"))
+}
+
+func TestStructs(t *testing.T) {
+	input := `
+	struct Point { x, y }
+	let p = Point { x: 1, y: 2 }
+	p`
+
+	evaluated := testEvaluate(input)
+	instance, ok := evaluated.(*object.Struct)
+	if !ok {
+		t.Fatalf("Object is not of type Struct! Instead received '%T' (%+v)", evaluated, evaluated)
+	}
+
+	if instance.Inspect() != "Point{x: 1, y: 2}" {
+		t.Errorf("Struct has the incorrect value! Expected %q but instead received %q", "Point{x: 1, y: 2}", instance.Inspect())
+	}
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"struct Point { x, y }; let p = Point { x: 1, y: 2 }; p.x + p.y", 3},
+		{"struct Point { x, y }; Point { y: 5, x: 4 }.y", 5},
+		{"struct Point { x, y }; let p = Point { x: 1, y: 2 }; let q = Point { x: 10, ..p }; q.x * q.y", 20},
+		{"struct Point { x, y }; let p = Point { x: 1, y: 2 }; let q = Point { x: 10, ..p }; p.x", 1},
+		{"struct Point { x, y }; struct Line { from, to }; let l = Line { from: Point { x: 1, y: 2 }, to: Point { x: 3, y: 4 } }; l.to.x", 3},
+		{"struct Point { x, y }; let p = Point { x: 1, y: 2 }; let (a, b) = (p.x, p.y); a - b", -1},
+		{"struct Point { x, y }; let q = Point { x: 1, y: 2 }; Point { x: 3, ..q }", "Point{x: 3, y: 2}"},
+		{"struct Wrapper { value }; Wrapper { value: (1, \"two\") }", "Wrapper{value: (1, two)}"},
+		{"struct Empty {}; Empty {}", "Empty{}"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("Struct has the incorrect value! Expected %q but instead received %+v", expected, evaluated)
+			}
+		}
+	}
+}
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc".upper()`, "ABC"},
+		{`"ABC".lower()`, "abc"},
+		{`"hello".len()`, 5},
+		{`let s = "a,b,c"; s.split(",")`, "(a, b, c)"},
+		{`"a,b,c".split(",").len()`, 3},
+		{`let (first, _) = "x-y".split("-"); first.upper()`, "X"},
+		{`(-5).abs()`, 5},
+		{`let n = -7; n.abs()`, 7},
+		{`let upper = "abc".upper; upper()`, "ABC"},
+		{`"abc" |> (s => s.upper())`, "ABC"},
+		{`struct Name { len }; Name { len: 10 }.len`, 10},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("Method call returned the incorrect value! Expected %q but instead received %+v", expected, evaluated)
+			}
+		}
+	}
+}
+
+func TestRegisterMethod(t *testing.T) {
+	eval := New()
+	eval.Builtins.RegisterMethod(object.BOOLEAN_OBJ, "toInt", func(args ...object.Object) object.Object {
+		if args[0] == TRUE {
+			return &object.Integer{Value: 1}
+		}
+		return &object.Integer{Value: 0}
+	})
+
+	program := parser.New(lexer.New("true.toInt() + (1 > 2).toInt()")).ParseProgram()
+	testIntegerObject(t, eval.Evaluate(program, object.NewEnvironment()), 1)
+}
+
+func TestBuiltinRegistry(t *testing.T) {
+	program := parser.New(lexer.New(`len("abcd")`)).ParseProgram()
+
+	first := New()
+	second := New()
+
+	first.Builtins.Register("len", func(args ...object.Object) object.Object {
+		return &object.Integer{Value: -1}
+	})
+	second.Builtins.Remove("len")
+
+	testIntegerObject(t, first.Evaluate(program, object.NewEnvironment()), -1)
+	testIntegerObject(t, New().Evaluate(program, object.NewEnvironment()), 4)
+
+	evaluated := second.Evaluate(program, object.NewEnvironment())
+	errorObject, ok := evaluated.(*object.Error)
+	if !ok || errorObject.Message != "Identifier Not Found: len" {
+		t.Errorf("Removed builtin should not be found! Instead received %+v", evaluated)
+	}
+
+	first.Builtins.RegisterMethod(object.STRING_OBJ, "first", func(args ...object.Object) object.Object {
+		return &object.String{Value: args[0].(*object.String).Value[:1]}
+	})
+	second.Builtins.RemoveMethod(object.STRING_OBJ, "upper")
+
+	method := parser.New(lexer.New(`"abc".first()`)).ParseProgram()
+	if evaluated := first.Evaluate(method, object.NewEnvironment()); evaluated.Inspect() != "a" {
+		t.Errorf("Registered method returned the incorrect value! Instead received %+v", evaluated)
+	}
+	if _, ok := New().Evaluate(method, object.NewEnvironment()).(*object.Error); !ok {
+		t.Errorf("Methods registered on one registry should not be visible to another")
+	}
+	if _, ok := second.Evaluate(parser.New(lexer.New(`"abc".upper()`)).ParseProgram(), object.NewEnvironment()).(*object.Error); !ok {
+		t.Errorf("Removed method should not be found")
+	}
+
+	shadowed := parser.New(lexer.New(`let len = fn(x) { 0 }; len("abc")`)).ParseProgram()
+	testIntegerObject(t, New().Evaluate(shadowed, object.NewEnvironment()), 0)
+
+	if names := NewBuiltins().Names(); len(names) != 0 {
+		t.Errorf("A new registry should be empty! Instead received %v", names)
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	program := parser.New(lexer.New(`
+	let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }
+	fib(50)`)).ParseProgram()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	evaluated := New().EvaluateContext(ctx, program, object.NewEnvironment())
+
+	errorObject, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+	}
+
+	if errorObject.Kind != object.CANCELLED_ERROR || errorObject.Message != "Cancelled: context deadline exceeded" {
+		t.Errorf("Object is not a cancellation error! Instead received %+v", errorObject)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	evaluated = New().EvaluateContext(cancelled, parser.New(lexer.New("1 + 1")).ParseProgram(), object.NewEnvironment())
+	if errorObject, ok := evaluated.(*object.Error); !ok || errorObject.Kind != object.CANCELLED_ERROR {
+		t.Errorf("Evaluation with a cancelled context should not start! Instead received %+v", evaluated)
+	}
+
+	testIntegerObject(t, testEvaluate("let f = fn(x) { x }; f(1)"), 1)
+}
+
+func TestResourceLimits(t *testing.T) {
+	tests := []struct {
+		input    string
+		limits   Limits
+		expected string
+	}{
+		{
+			"let f = fn(n) { if (n == 0) { 0 } else { f(n - 1) } }; f(1000)",
+			Limits{MaxSteps: 500},
+			"Resource Exhausted: step limit of 500 exceeded",
+		},
+		{
+			"let f = fn(n) { if (n == 0) { 0 } else { f(n - 1) } }; f(1000)",
+			Limits{MaxAllocations: 100},
+			"Resource Exhausted: allocation limit of 100 exceeded",
+		},
+		{
+			`let grow = fn(s, n) { if (n == 0) { s } else { grow(s + s, n - 1) } }; grow("ab", 20)`,
+			Limits{MaxBytes: 4096},
+			"Resource Exhausted: memory limit of 4096 bytes exceeded",
+		},
+		{
+			`let grow = fn(s, n) { if (n == 0) { s } else { grow(s + s, n - 1) } }; grow("ab", 20)`,
+			Limits{MaxStringLength: 100},
+			"Resource Exhausted: string length limit of 100 exceeded",
+		},
+		{
+			"let f = fn(n) { f(n + 1) }; f(0)",
+			Limits{MaxDepth: 50},
+			"Resource Exhausted: call depth limit of 50 exceeded",
+		},
+		{
+			`"abcdefghijkl"`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+		{
+			`"ab".repeat(400000000)`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+		{
+			`"aaaa".replace("a", "bbbb")`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+		{
+			`join(", ", "abc", "def", "ghi")`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+		{
+			`format("%8d%8d", 1, 2)`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+		{
+			`pad(20)`,
+			Limits{MaxStringLength: 10},
+			"Resource Exhausted: string length limit of 10 exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		eval := New()
+		eval.Limits = tt.limits
+		eval.Builtins.RegisterContext("pad", func(ctx context.Context, args ...object.Object) object.Object {
+			length := int(args[0].(*object.Integer).Value)
+			if err := CheckStringLength(ctx, length); err != nil {
+				return err
+			}
+			return &object.String{Value: strings.Repeat(" ", length)}
+		})
+
+		evaluated := eval.Evaluate(parser.New(lexer.New(tt.input)).ParseProgram(), object.NewEnvironment())
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Kind != object.RESOURCE_EXHAUSTED_ERROR || errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error! Expected '%s' but receieved %+v", tt.expected, errorObject)
+		}
+	}
+
+	eval := New()
+	eval.Limits = Limits{MaxSteps: 1000, MaxStringLength: 10}
+	program := parser.New(lexer.New(`let f = fn(a, b) { a + b }; f("ab", "cd")`)).ParseProgram()
+
+	evaluated := eval.Evaluate(program, object.NewEnvironment())
+	if str, ok := evaluated.(*object.String); !ok || str.Value != "abcd" {
+		t.Fatalf("Program within its limits returned the incorrect value! Instead received %+v", evaluated)
+	}
+
+	usage := eval.Usage()
+	if usage.Steps == 0 || usage.Allocations == 0 || usage.Bytes == 0 {
+		t.Errorf("Usage was not recorded! Instead received %+v", usage)
+	}
+
+	eval.ResetUsage()
+	if eval.Usage() != (Usage{}) {
+		t.Errorf("Usage was not reset! Instead received %+v", eval.Usage())
+	}
+}
+
+func TestFrozenEnvironment(t *testing.T) {
+	shared := object.NewEnvironment()
+	New().Evaluate(parser.New(lexer.New(`let total = 1; let bump = fn() { total = total + 1 }`)).ParseProgram(), shared)
+	shared.Freeze()
+
+	tests := []struct {
+		input    string
+		env      *object.Environment
+		expected string
+	}{
+		{"bump()", object.NewEnclosedEnvironment(shared), "Frozen Binding: cannot assign to total, it is defined in a frozen environment"},
+		{"total = 5", object.NewEnclosedEnvironment(shared), "Frozen Binding: cannot assign to total, it is defined in a frozen environment"},
+		{"let x = 1", shared, "Frozen Environment: programs cannot be evaluated in a frozen environment"},
+	}
+
+	for _, tt := range tests {
+		evaluated := New().Evaluate(parser.New(lexer.New(tt.input)).ParseProgram(), tt.env)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+
+	child := object.NewEnclosedEnvironment(shared)
+	testIntegerObject(t, New().Evaluate(parser.New(lexer.New("let total = 10; total = total + 1; total")).ParseProgram(), child), 11)
+
+	if value, _ := shared.Get("total"); value.Inspect() != "1" {
+		t.Errorf("Frozen environment was changed! total is %s", value.Inspect())
+	}
+}
+
+func TestClasses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Counter {
+			init(self) { self.n = 0 }
+			inc(self) { self.n += 1 }
+		}
+		let c = Counter()
+		c.inc()
+		c.inc()
+		c.n`, 2},
+		{`
+		class Point {
+			init(self, x, y) {
+				self.x = x
+				self.y = y
+			}
+			sum(self) { self.x + self.y }
+		}
+		Point(3, 4).sum()`, 7},
+		{`
+		class Point {
+			init(self, x, y) {
+				self.x = x
+				self.y = y
+			}
+		}
+		Point(3, 4)`, "Point{x: 3, y: 4}"},
+		{`
+		class Counter {
+			init(self) { self.n = 10 }
+			inc(self) { self.n += 1 }
+		}
+		let c = Counter()
+		let inc = c.inc
+		inc()
+		inc()
+		c.n`, 12},
+		{`
+		class Animal {
+			init(self, name) { self.name = name }
+			speak(self) { self.name + " makes a sound" }
+		}
+		class Dog(Animal) {
+			speak(self) { super.speak() + " (woof)" }
+		}
+		Dog("Rex").speak()`, "Rex makes a sound (woof)"},
+		{`
+		class A {
+			init(self) { self.log = "A" }
+		}
+		class B(A) {
+			init(self) {
+				super.init()
+				self.log += "B"
+			}
+		}
+		class C(B) {
+			init(self) {
+				super.init()
+				self.log += "C"
+			}
+		}
+		C().log`, "ABC"},
+		{`
+		class Shape {
+			area(self) { 0 }
+			describe(self) { self.area() }
+		}
+		class Square(Shape) {
+			init(self, side) { self.side = side }
+			area(self) { self.side * self.side }
+		}
+		Square(3).describe()`, 9},
+		{`class Empty {}; Empty()`, "Empty{}"},
+		{`class Empty {}; Empty`, "class Empty"},
+		{`let x = 1; x = x + 1; x += 10; x`, 12},
+		{`let x = 1; let f = fn() { x = 5 }; f(); x`, 5},
+		{`let a = 0; let b = 0; a = b = 3; a + b`, 6},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if evaluated == nil || evaluated.Inspect() != expected {
+				t.Errorf("Class evaluation returned the incorrect value! Expected %q but instead received %+v", expected, evaluated)
+			}
+		}
+	}
+}
+
+// A vector type as an embedding application might define it, supporting +, scaling and equality
+type testVector struct {
+	x, y int64
+}
+
+func (v *testVector) Type() object.ObjectType { return "VECTOR" }
+func (v *testVector) Inspect() string         { return fmt.Sprintf("<%d, %d>", v.x, v.y) }
+
+func (v *testVector) Operate(operator string, other object.Object) object.Object {
+	switch other := other.(type) {
+	case *testVector:
+		if operator == "+" {
+			return &testVector{x: v.x + other.x, y: v.y + other.y}
+		}
+	case *object.Integer:
+		if operator == "*" {
+			return &testVector{x: v.x * other.Value, y: v.y * other.Value}
+		}
+	}
+	return nil
+}
+
+func (v *testVector) OperateReflected(operator string, other object.Object) object.Object {
+	if operator == "*" {
+		return v.Operate(operator, other)
+	}
+	return nil
+}
+
+func (v *testVector) Equals(other object.Object) bool {
+	o, ok := other.(*testVector)
+	return ok && o.x == v.x && o.y == v.y
+}
+
+// A money type which compares amounts and refuses to mix currencies
+type testMoney struct {
+	cents    int64
+	currency string
+}
+
+func (m *testMoney) Type() object.ObjectType { return "MONEY" }
+func (m *testMoney) Inspect() string         { return m.String() }
+func (m *testMoney) String() string {
+	return fmt.Sprintf("%d.%02d %s", m.cents/100, m.cents%100, m.currency)
+}
+
+func (m *testMoney) Operate(operator string, other object.Object) object.Object {
+	o, ok := other.(*testMoney)
+	if !ok {
+		return nil
+	}
+
+	if o.currency != m.currency {
+		return &object.Error{Message: fmt.Sprintf("Currency Mismatch: %s and %s", m.currency, o.currency)}
+	}
+
+	switch operator {
+	case "+":
+		return &testMoney{cents: m.cents + o.cents, currency: m.currency}
+	case "<":
+		return nativeBoolToBooleanObject(m.cents < o.cents)
+	case ">":
+		return nativeBoolToBooleanObject(m.cents > o.cents)
+	}
+	return nil
+}
+
+func TestOperatorOverloading(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a + b", "<4, 6>"},
+		{"a * 3", "<3, 6>"},
+		{"2 * a", "<2, 4>"},
+		{"a == same", "true"},
+		{"a != same", "false"},
+		{"a == b", "false"},
+		{"a + b == b + a", "true"},
+		{"let v = a; v += b; v", "<4, 6>"},
+		{"price + tax", "12.50 USD"},
+		{"price < tax", "false"},
+		{"tax < price", "true"},
+		{`"total: " + price`, "total: 10.00 USD"},
+		{`price + " total"`, "10.00 USD total"},
+		{"price + euros", "Currency Mismatch: USD and EUR"},
+		{"a - b", "Unknown Operator: VECTOR - VECTOR"},
+		{"a + price", "Type Mismatch: VECTOR + MONEY"},
+		{"a + 1", "Type Mismatch: VECTOR + INTEGER"},
+	}
+
+	for _, tt := range tests {
+		env := object.NewEnvironment()
+		env.Set("a", &testVector{x: 1, y: 2})
+		env.Set("same", &testVector{x: 1, y: 2})
+		env.Set("b", &testVector{x: 3, y: 4})
+		env.Set("price", &testMoney{cents: 1000, currency: "USD"})
+		env.Set("tax", &testMoney{cents: 250, currency: "USD"})
+		env.Set("euros", &testMoney{cents: 100, currency: "EUR"})
+
+		program := parser.New(lexer.New(tt.input)).ParseProgram()
+		evaluated := Evaluate(program, env)
+
+		if evaluated == nil || evaluated.Inspect() != tt.expected {
+			t.Errorf("Overloaded operator returned the incorrect value for %q! Expected %q but instead received %+v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
+
 func TestStringLiteral(t *testing.T) {
 	input := `"hello world";`
 
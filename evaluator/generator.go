@@ -0,0 +1,99 @@
+package evaluator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/armansandhu/monkey_interpreter/ast"
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// The built-in function and method for iterating over a generator. Both return the next value the generator yields,
+// or null once its body has finished.
+var generatorNext = &object.BuiltIn{
+	ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+		if len(args) != 1 {
+			return newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+		}
+
+		generator, ok := args[0].(*object.Generator)
+		if !ok {
+			return newError("Argument to `next` is not supported! Instead received an %s!", args[0].Type())
+		}
+
+		value, err := generator.Next(ctx)
+		if errors.Is(err, object.ErrGeneratorRunning) {
+			return newError("Generator Running: a generator cannot ask for its own next value")
+		}
+		if err != nil {
+			return cancelled(err)
+		}
+
+		if value == nil {
+			return NULL
+		}
+		return value
+	},
+}
+
+func init() {
+	builtins["next"] = generatorNext
+	methods[object.GENERATOR_OBJ] = map[string]*object.BuiltIn{"next": generatorNext}
+}
+
+// This method stops every generator and task created by programs run by this Evaluator or the tasks they spawned.
+// Generators are also stopped when they are closed or garbage collected, but one which is reachable from its own
+// environment, such as a generator stored in a global, is only stopped by Close. Tasks waiting on a channel are
+// cancelled and complete with a cancellation error.
+func (e *Evaluator) Close() {
+	e.stop()
+}
+
+// This method creates the generator returned by calling a generator function. The body is evaluated on the generator's
+// own goroutine by a copy of e, which is suspended at each yield until the next value is requested.
+func (e *Evaluator) newGenerator(body *ast.BlockStatement, env *object.Environment) *object.Generator {
+	child := e.fork()
+
+	return object.NewGenerator(e.lifetime.Done(), func(ctx context.Context, y *object.Yielder) (result object.Object) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = newError("Generator Failed: %v", r)
+			}
+		}()
+
+		child.ctx = ctx
+		child.yielder = y
+
+		evaluated := unWrapReturnValue(child.Evaluate(body, env))
+		if isError(evaluated) {
+			return evaluated
+		}
+
+		// The value a generator returns is not handed to its caller, which only sees the values it yields
+		return nil
+	})
+}
+
+// This method hands a value to the caller of next and waits until the generator is resumed.
+// A yield evaluates to null, and stops the generator's body with an error if the generator was closed while suspended.
+func (e *Evaluator) evaluateYieldExpression(ye *ast.YieldExpression, env *object.Environment) object.Object {
+	if e.yielder == nil {
+		return newError("Invalid Yield: yield can only be used inside a generator")
+	}
+
+	var value object.Object = NULL
+	if ye.Value != nil {
+		value = e.Evaluate(ye.Value, env)
+		if isError(value) {
+			return value
+		}
+	}
+
+	ctx, ok := e.yielder.Yield(value)
+	if !ok {
+		return &object.Error{Message: "Generator Closed: the generator was closed", Kind: object.CANCELLED_ERROR}
+	}
+
+	e.ctx = ctx
+	return NULL
+}
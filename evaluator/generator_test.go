@@ -0,0 +1,146 @@
+package evaluator
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let gen = fn() { yield 1; yield 2 }; let g = gen(); g.next() + g.next()`, 3},
+		{`let gen = fn() { yield 1 }; let g = gen(); g.next(); g.next()`, nil},
+		{`let gen = fn() { yield 1 }; let g = gen(); g.next(); g.next(); g.next()`, nil},
+		{`let gen = fn() { yield }; gen().next()`, nil},
+		{"let gen = fn() {\n\tyield\n\t1\n}\ngen().next()", nil},
+		{`let gen = fn(n) { yield n; return 5; yield n + 1 }; let g = gen(7); g.next(); g.next()`, nil},
+		{`let gen = fn(n) { yield n * 2 }; next(gen(21))`, 42},
+		{`let naturals = fn() { let loop = fn(i) { i }; yield 1; yield 2; yield 3 }
+let g = naturals()
+let sum = fn(total) { let v = g.next(); if (v == null) { total } else { sum(total + v) } }
+sum(0)`, 6},
+		{`let counter = fn() { let n = 0; let inc = fn() { n += 1 }; yield inc(); yield inc(); yield inc() }
+let g = counter()
+g.next(); g.next(); g.next()`, 3},
+		{`let a = fn() { yield 1; yield 2 }; let b = fn() { yield 10; yield 20 }
+let x = a(); let y = b()
+x.next() + y.next() + x.next() + y.next()`, 33},
+		{`class Pair { init(self, a, b) { self.a = a; self.b = b } each(self) { yield self.a; yield self.b } }
+let g = Pair(4, 5).each()
+g.next() * g.next()`, 20},
+		{`let squares = x => yield x * x; squares(9).next()`, 81},
+		{`let gen = fn() { yield 1; yield 2 }; let g = gen(); g.next(); close(g); g.next()`, nil},
+		{`let gen = fn() { yield 1 }; let g = gen(); close(g); g.next()`, nil},
+		{`let inner = fn() { yield 1; yield 2 }
+let outer = fn() { let g = inner(); yield g.next() * 10; yield g.next() * 10 }
+let g = outer()
+g.next() + g.next()`, 30},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let gen = fn() { yield 1; 1 + true }; let g = gen(); g.next(); g.next()`, "Type Mismatch: INTEGER + BOOLEAN"},
+		{`let gen = fn() { yield missing }; gen().next()`, "Identifier Not Found: missing"},
+		{`let gen = fn(a) { yield a }; gen()`, "Incorrect number of arguments detected! Needed 1 but instead received 0!"},
+		{`next(5)`, "Argument to `next` is not supported! Instead received an INTEGER!"},
+		{`close(5)`, "Argument to `close` is not supported! Instead received an INTEGER!"},
+		{`let gen = fn() { yield g.next() }; let g = gen(); g.next()`, "Generator Running: a generator cannot ask for its own next value"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
+
+func TestGeneratorResumedWithNewContext(t *testing.T) {
+	env := object.NewEnvironment()
+	eval := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	program := parser.New(lexer.New(`let gen = fn() { yield 1; yield 2 }; let g = gen(); g.next()`)).ParseProgram()
+	testIntegerObject(t, eval.EvaluateContext(ctx, program, env), 1)
+	cancel()
+
+	program = parser.New(lexer.New(`g.next()`)).ParseProgram()
+	testIntegerObject(t, eval.EvaluateContext(context.Background(), program, env), 2)
+}
+
+// This helper function waits for the number of running goroutines to fall back to before, reporting whether it did
+func waitForGoroutines(before int) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	return runtime.NumGoroutine() <= before
+}
+
+func TestAbandonedGeneratorsStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	env := object.NewEnvironment()
+	eval := New()
+	program := parser.New(lexer.New(`let gen = fn() { yield 1; yield 2 }
+let peek = fn() { let g = gen(); g.next() }`)).ParseProgram()
+	eval.Evaluate(program, env)
+
+	program = parser.New(lexer.New(`peek()`)).ParseProgram()
+	for i := 0; i < 20; i++ {
+		testIntegerObject(t, eval.Evaluate(program, env), 1)
+	}
+
+	if !waitForGoroutines(before) {
+		t.Errorf("Abandoned generators were not stopped! %d goroutines were running before and %d after", before, runtime.NumGoroutine())
+	}
+}
+
+func TestEvaluatorCloseStopsGenerators(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	env := object.NewEnvironment()
+	eval := New()
+	program := parser.New(lexer.New(`let gen = fn() { yield 1; yield 2 }; let g = gen(); g.next()`)).ParseProgram()
+	testIntegerObject(t, eval.Evaluate(program, env), 1)
+
+	eval.Close()
+
+	if !waitForGoroutines(before) {
+		t.Errorf("Closing the evaluator did not stop its generators! %d goroutines were running before and %d after", before, runtime.NumGoroutine())
+	}
+
+	program = parser.New(lexer.New(`g.next()`)).ParseProgram()
+	testNullObject(t, eval.Evaluate(program, env))
+}
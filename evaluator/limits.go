@@ -0,0 +1,173 @@
+package evaluator
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// Limits caps the resources which the programs run by an Evaluator may use. A limit of zero means no limit, except for
+// MaxDepth which falls back to DefaultMaxDepth. Usage is counted from when the Evaluator is created or ResetUsage is last called.
+type Limits struct {
+	// MaxSteps caps the number of nodes evaluated
+	MaxSteps int64
+	// MaxAllocations caps the number of values and environments created
+	MaxAllocations int64
+	// MaxBytes caps the approximate number of bytes held by the values and environments created
+	MaxBytes int64
+	// MaxStringLength caps the length in bytes of any string produced
+	MaxStringLength int
+	// MaxDepth caps the number of function calls in progress at once on one goroutine, so that runaway recursion
+	// returns an error instead of overflowing the Go stack
+	MaxDepth int
+}
+
+// DefaultMaxDepth is the call depth limit used when Limits.MaxDepth is zero
+const DefaultMaxDepth = 10000
+
+// Usage reports the resources used by the programs run by an Evaluator
+type Usage struct {
+	Steps       int64
+	Allocations int64
+	Bytes       int64
+}
+
+// approximate sizes used when accounting for allocations
+const (
+	objectSize      = 16
+	referenceSize   = 8
+	environmentSize = 48
+)
+
+// This method returns the resources used since the Evaluator was created or ResetUsage was last called,
+// including those used by tasks the programs spawned
+func (e *Evaluator) Usage() Usage {
+	return Usage{
+		Steps:       atomic.LoadInt64(&e.usage.Steps),
+		Allocations: atomic.LoadInt64(&e.usage.Allocations),
+		Bytes:       atomic.LoadInt64(&e.usage.Bytes),
+	}
+}
+
+// This method sets the resources used back to zero, so that the limits apply afresh to the next run
+func (e *Evaluator) ResetUsage() {
+	atomic.StoreInt64(&e.usage.Steps, 0)
+	atomic.StoreInt64(&e.usage.Allocations, 0)
+	atomic.StoreInt64(&e.usage.Bytes, 0)
+}
+
+// This helper method counts an evaluation step, returning an error once the step limit has been passed.
+// Usage is shared with the Evaluators running spawned tasks, so it is updated atomically.
+func (e *Evaluator) step() *object.Error {
+	steps := atomic.AddInt64(&e.usage.Steps, 1)
+	if e.Limits.MaxSteps > 0 && steps > e.Limits.MaxSteps {
+		return resourceExhausted("step limit of %d exceeded", e.Limits.MaxSteps)
+	}
+	return nil
+}
+
+// This helper method enters a function call, returning an error if the call depth limit would be passed.
+// The depth belongs to the goroutine running e, so spawned tasks and generators start again from zero.
+func (e *Evaluator) enterCall() *object.Error {
+	limit := e.Limits.MaxDepth
+	if limit <= 0 {
+		limit = DefaultMaxDepth
+	}
+
+	if e.depth >= limit {
+		return resourceExhausted("call depth limit of %d exceeded", limit)
+	}
+	e.depth++
+	return nil
+}
+
+// This helper method counts an allocation of the given size, returning an error once an allocation limit has been passed
+func (e *Evaluator) allocate(bytes int64) *object.Error {
+	allocations := atomic.AddInt64(&e.usage.Allocations, 1)
+	total := atomic.AddInt64(&e.usage.Bytes, bytes)
+
+	if e.Limits.MaxAllocations > 0 && allocations > e.Limits.MaxAllocations {
+		return resourceExhausted("allocation limit of %d exceeded", e.Limits.MaxAllocations)
+	}
+	if e.Limits.MaxBytes > 0 && total > e.Limits.MaxBytes {
+		return resourceExhausted("memory limit of %d bytes exceeded", e.Limits.MaxBytes)
+	}
+	return nil
+}
+
+// This helper method counts the allocation of a newly created value.
+// It returns the value, or an error if the value breaks a limit. Errors and the shared constants are not counted.
+func (e *Evaluator) account(obj object.Object) object.Object {
+	switch obj {
+	case nil, TRUE, FALSE, NULL:
+		return obj
+	}
+
+	var size int64 = objectSize
+
+	switch obj := obj.(type) {
+	case *object.Error:
+		return obj
+	case *object.String:
+		if err := e.checkStringLength(len(obj.Value)); err != nil {
+			return err
+		}
+		size += int64(len(obj.Value))
+	case *object.Tuple:
+		size += int64(len(obj.Elements)) * referenceSize
+	case *object.Struct:
+		size += int64(len(obj.Fields)) * 2 * referenceSize
+	case *object.Instance:
+		size += int64(len(obj.FieldNames())) * 2 * referenceSize
+	case *object.Channel:
+		size += int64(obj.Capacity) * referenceSize
+	case *object.Function:
+		size += environmentSize
+	}
+
+	if err := e.allocate(size); err != nil {
+		return err
+	}
+	return obj
+}
+
+// This helper method returns an error if a string of the given length would break the string length limit
+func (e *Evaluator) checkStringLength(length int) *object.Error {
+	if e.Limits.MaxStringLength > 0 && length > e.Limits.MaxStringLength {
+		return resourceExhausted("string length limit of %d exceeded", e.Limits.MaxStringLength)
+	}
+	return nil
+}
+
+// This function returns an error if a string of the given length would break the string length limit of the Evaluator
+// calling a builtin. Builtins which build strings call it with the length of the result before building it.
+func CheckStringLength(ctx context.Context, length int) *object.Error {
+	e, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return e.checkStringLength(length)
+}
+
+// This helper method applies an infix operator, refusing to build strings which are too long and accounting for the result
+func (e *Evaluator) applyInfixOperator(left object.Object, operator string, right object.Object) object.Object {
+	if operator == "+" {
+		leftString, leftOk := left.(*object.String)
+		rightString, rightOk := right.(*object.String)
+		if leftOk && rightOk {
+			if err := e.checkStringLength(len(leftString.Value) + len(rightString.Value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.account(evaluateInfixExpression(left, operator, right))
+}
+
+// This helper function creates the error reported when a program breaks one of its limits
+func resourceExhausted(format string, a ...interface{}) *object.Error {
+	err := newError("Resource Exhausted: "+format, a...)
+	err.Kind = object.RESOURCE_EXHAUSTED_ERROR
+	return err
+}
@@ -0,0 +1,242 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// The integer methods of the standard library, added to abs which is declared with the other methods in builtin.go.
+// Arguments outside a function's domain, such as the square root of a negative number, and results which do not fit in
+// 64 bits are reported as errors.
+var integerMethods = map[string]object.BuiltInFunction{
+	"min": func(args ...object.Object) object.Object {
+		values, err := integerArguments("min", args, len(args)-1)
+		if err != nil {
+			return err
+		}
+
+		result := values[0]
+		for _, value := range values[1:] {
+			if value < result {
+				result = value
+			}
+		}
+		return &object.Integer{Value: result}
+	},
+	"max": func(args ...object.Object) object.Object {
+		values, err := integerArguments("max", args, len(args)-1)
+		if err != nil {
+			return err
+		}
+
+		result := values[0]
+		for _, value := range values[1:] {
+			if value > result {
+				result = value
+			}
+		}
+		return &object.Integer{Value: result}
+	},
+	"pow": func(args ...object.Object) object.Object {
+		values, err := integerArguments("pow", args, 1)
+		if err != nil {
+			return err
+		}
+
+		base, exponent := values[0], values[1]
+		if exponent < 0 {
+			return domainError("`pow` needs a non-negative exponent! Instead received %d!", exponent)
+		}
+
+		result, ok := int64(1), true
+		for ; exponent > 0; exponent >>= 1 {
+			if exponent&1 == 1 {
+				if result, ok = multiply(result, base); !ok {
+					return integerOverflow("pow")
+				}
+			}
+			if exponent > 1 {
+				if base, ok = multiply(base, base); !ok {
+					return integerOverflow("pow")
+				}
+			}
+		}
+		return &object.Integer{Value: result}
+	},
+	"sqrt": func(args ...object.Object) object.Object {
+		values, err := integerArguments("sqrt", args, 0)
+		if err != nil {
+			return err
+		}
+
+		value := values[0]
+		if value < 0 {
+			return domainError("`sqrt` is not defined for negative numbers! Instead received %d!", value)
+		}
+
+		// The floating point estimate can be off by one for large values, so it is corrected to the exact floor
+		root := uint64(math.Sqrt(float64(value)))
+		for root*root > uint64(value) {
+			root--
+		}
+		for (root+1)*(root+1) <= uint64(value) {
+			root++
+		}
+		return &object.Integer{Value: int64(root)}
+	},
+	"gcd": func(args ...object.Object) object.Object {
+		values, err := integerArguments("gcd", args, 1)
+		if err != nil {
+			return err
+		}
+
+		result := gcd(values[0], values[1])
+		if result > math.MaxInt64 {
+			return integerOverflow("gcd")
+		}
+		return &object.Integer{Value: int64(result)}
+	},
+	"lcm": func(args ...object.Object) object.Object {
+		values, err := integerArguments("lcm", args, 1)
+		if err != nil {
+			return err
+		}
+
+		a, b := values[0], values[1]
+		if a == 0 || b == 0 {
+			return &object.Integer{Value: 0}
+		}
+
+		result, ok := multiply(a/int64(gcd(a, b)), b)
+		if !ok || result == math.MinInt64 {
+			return integerOverflow("lcm")
+		}
+		if result < 0 {
+			result = -result
+		}
+		return &object.Integer{Value: result}
+	},
+	"clamp": func(args ...object.Object) object.Object {
+		values, err := integerArguments("clamp", args, 2)
+		if err != nil {
+			return err
+		}
+
+		value, low, high := values[0], values[1], values[2]
+		if low > high {
+			return domainError("`clamp` needs a lower bound no greater than its upper bound! Instead received %d and %d!", low, high)
+		}
+
+		return &object.Integer{Value: min(max(value, low), high)}
+	},
+	"log": func(args ...object.Object) object.Object {
+		values, err := integerArguments("log", args, 1)
+		if err != nil {
+			return err
+		}
+
+		value, base := values[0], values[1]
+		if value <= 0 {
+			return domainError("`log` is only defined for positive numbers! Instead received %d!", value)
+		}
+		if base < 2 {
+			return domainError("`log` needs a base of at least 2! Instead received %d!", base)
+		}
+
+		// The result is rounded down, so log(1000, 10) is 3 and log(999, 10) is 2
+		result := int64(0)
+		for value >= base {
+			value /= base
+			result++
+		}
+		return &object.Integer{Value: result}
+	},
+	"modPow": func(args ...object.Object) object.Object {
+		values, err := integerArguments("modPow", args, 2)
+		if err != nil {
+			return err
+		}
+
+		base, exponent, modulus := values[0], values[1], values[2]
+		if exponent < 0 {
+			return domainError("`modPow` needs a non-negative exponent! Instead received %d!", exponent)
+		}
+		if modulus <= 0 {
+			return domainError("`modPow` needs a positive modulus! Instead received %d!", modulus)
+		}
+
+		m := big.NewInt(modulus)
+		b := new(big.Int).Mod(big.NewInt(base), m)
+		result := new(big.Int).Exp(b, big.NewInt(exponent), m)
+		return &object.Integer{Value: result.Int64()}
+	},
+}
+
+func init() {
+	for name, function := range integerMethods {
+		methods[object.INTEGER_OBJ][name] = &object.BuiltIn{Function: function}
+	}
+
+	// Every integer method is also a builtin which takes the integer as its first argument, such as sqrt(16) or max(1, 5, 3)
+	registerMethodFunctions(object.INTEGER_OBJ)
+}
+
+// This helper function checks the arguments of an integer method, returning their values along with the receiver's
+func integerArguments(name string, args []object.Object, expected int) ([]int64, *object.Error) {
+	if err := checkMethodArguments(name, args, expected); err != nil {
+		return nil, err
+	}
+
+	values := make([]int64, len(args))
+	for index, arg := range args {
+		integer, ok := arg.(*object.Integer)
+		if !ok {
+			return nil, newError("Argument to `%s` is not supported! Instead received an %s!", name, arg.Type())
+		}
+		values[index] = integer.Value
+	}
+
+	return values, nil
+}
+
+// This helper function multiplies two integers, reporting false if the result does not fit in 64 bits
+func multiply(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	result := a * b
+	if result/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return result, true
+}
+
+// This helper function returns the greatest common divisor of the magnitudes of a and b, which is 2^63 for gcd(MinInt64, 0)
+func gcd(a, b int64) uint64 {
+	x, y := magnitude(a), magnitude(b)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return x
+}
+
+// This helper function returns the absolute value of n, which cannot overflow as an unsigned integer
+func magnitude(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
+
+// This helper function creates the error reported when a math function receives an argument outside its domain
+func domainError(format string, a ...interface{}) *object.Error {
+	return newError("Domain Error: "+format, a...)
+}
+
+// This helper function creates the error reported when the result of a math function does not fit in 64 bits
+func integerOverflow(name string) *object.Error {
+	return newError("Integer Overflow: the result of `%s` does not fit in 64 bits!", name)
+}
@@ -0,0 +1,94 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+func TestMathFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{`abs(-5)`, 5},
+		{`min(4, 2, 8)`, 2},
+		{`max(4, 2, 8)`, 8},
+		{`min(7)`, 7},
+		{`pow(2, 10)`, 1024},
+		{`pow(-3, 3)`, -27},
+		{`pow(5, 0)`, 1},
+		{`pow(0, 0)`, 1},
+		{`pow(2, 62)`, 4611686018427387904},
+		{`pow(-2, 63)`, -9223372036854775808},
+		{`sqrt(0)`, 0},
+		{`sqrt(16)`, 4},
+		{`sqrt(17)`, 4},
+		{`sqrt(9223372036854775807)`, 3037000499},
+		{`gcd(12, 18)`, 6},
+		{`gcd(-12, 18)`, 6},
+		{`gcd(0, 5)`, 5},
+		{`lcm(4, 6)`, 12},
+		{`lcm(-4, 6)`, 12},
+		{`lcm(0, 6)`, 0},
+		{`clamp(5, 0, 3)`, 3},
+		{`clamp(-5, 0, 3)`, 0},
+		{`clamp(2, 0, 3)`, 2},
+		{`log(1000, 10)`, 3},
+		{`log(999, 10)`, 2},
+		{`log(1, 2)`, 0},
+		{`log(1024, 2)`, 10},
+		{`modPow(4, 13, 497)`, 445},
+		{`modPow(-2, 3, 5)`, 2},
+		{`modPow(3, 0, 1)`, 0},
+		{`modPow(9223372036854775807, 9223372036854775807, 1000000007)`, 856225998},
+		{`(2).pow(8)`, 256},
+		{`(81).sqrt()`, 9},
+		{`let n = 10; n.clamp(0, 5)`, 5},
+		{`(3).max(9, 4)`, 9},
+		{`16 |> sqrt |> (x => pow(x, 2))`, 16},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestMathErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`sqrt(-4)`, "Domain Error: `sqrt` is not defined for negative numbers! Instead received -4!"},
+		{`pow(2, -1)`, "Domain Error: `pow` needs a non-negative exponent! Instead received -1!"},
+		{`modPow(2, 3, 0)`, "Domain Error: `modPow` needs a positive modulus! Instead received 0!"},
+		{`modPow(2, -3, 5)`, "Domain Error: `modPow` needs a non-negative exponent! Instead received -3!"},
+		{`log(0, 10)`, "Domain Error: `log` is only defined for positive numbers! Instead received 0!"},
+		{`log(8, 1)`, "Domain Error: `log` needs a base of at least 2! Instead received 1!"},
+		{`clamp(1, 5, 0)`, "Domain Error: `clamp` needs a lower bound no greater than its upper bound! Instead received 5 and 0!"},
+		{`5 / 0`, "Domain Error: cannot divide 5 by zero!"},
+		{`pow(2, 63)`, "Integer Overflow: the result of `pow` does not fit in 64 bits!"},
+		{`pow(10, 19)`, "Integer Overflow: the result of `pow` does not fit in 64 bits!"},
+		{`abs(-9223372036854775807 - 1)`, "Integer Overflow: the result of `abs` does not fit in 64 bits!"},
+		{`lcm(9223372036854775807, 9223372036854775806)`, "Integer Overflow: the result of `lcm` does not fit in 64 bits!"},
+		{`gcd(-9223372036854775807 - 1, 0)`, "Integer Overflow: the result of `gcd` does not fit in 64 bits!"},
+		{`sqrt("a")`, "Argument to `sqrt` is not supported! Instead received an STRING!"},
+		{`max(1, "a")`, "Argument to `max` is not supported! Instead received an STRING!"},
+		{`pow(2)`, "Incorrect number of arguments to `pow` detected! Only needed 1 but instead received 0!"},
+		{`min()`, "Incorrect number of arguments detected! Needed at least 1 but instead received 0!"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
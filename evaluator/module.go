@@ -0,0 +1,223 @@
+package evaluator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armansandhu/monkey_interpreter/ast"
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+// the extension given to module paths which are imported without one
+const ModuleExtension = ".monkey"
+
+// This method reads, parses and evaluates the file at path in env
+// Imports made by the file are resolved relative to the directory containing it
+func (e *Evaluator) EvaluateFile(path string, env *object.Environment) object.Object {
+	return e.EvaluateFileContext(e.ctx, path, env)
+}
+
+// This method evaluates the file at path like EvaluateFile, stopping with a cancellation error once ctx is done
+func (e *Evaluator) EvaluateFileContext(ctx context.Context, path string, env *object.Environment) object.Object {
+	defer e.useContext(ctx)()
+
+	if err := e.checkCancelled(); err != nil {
+		return err
+	}
+
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return newError("Import Error: %s", err)
+	}
+
+	program, errObj := readModule(absolute)
+	if errObj != nil {
+		return errObj
+	}
+
+	e.loading = append(e.loading, absolute)
+	defer func() { e.loading = e.loading[:len(e.loading)-1] }()
+
+	return e.Evaluate(program, env)
+}
+
+// This method binds the module named by an import statement, loading it first if it has not been loaded before
+func (e *Evaluator) evaluateImportStatement(is *ast.ImportStatement, env *object.Environment) object.Object {
+	module := e.importModule(is.Path)
+	if isError(module) {
+		return module
+	}
+
+	name := strings.TrimSuffix(filepath.Base(is.Path), filepath.Ext(is.Path))
+	if is.Alias != nil {
+		name = is.Alias.Value
+	}
+
+	env.Set(name, module)
+	return nil
+}
+
+// This method returns the module for path from the cache, or evaluates it into its own environment and caches it
+// Importing reads files, so it needs the fs-read capability and the module's file must be readable
+func (e *Evaluator) importModule(path string) object.Object {
+	if err := e.require("import", FS_READ); err != nil {
+		return err
+	}
+
+	filename, errObj := e.resolveModule(path)
+	if errObj != nil {
+		return errObj
+	}
+
+	if module, ok := e.modules[filename]; ok {
+		return module
+	}
+
+	for i, loading := range e.loading {
+		if loading == filename {
+			var cycle []string
+			for _, name := range e.loading[i:] {
+				cycle = append(cycle, filepath.Base(name))
+			}
+			cycle = append(cycle, filepath.Base(filename))
+			return newError("Import Cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	program, errObj := readModule(filename)
+	if errObj != nil {
+		return errObj
+	}
+
+	env := object.NewEnvironment()
+
+	e.loading = append(e.loading, filename)
+	result := e.Evaluate(program, env)
+	e.loading = e.loading[:len(e.loading)-1]
+
+	if isError(result) {
+		return result
+	}
+
+	module := &object.Module{Name: path, Path: filename, Exports: make(map[string]object.Object)}
+	for _, name := range exportedNames(program) {
+		if value, ok := env.Get(name); ok {
+			module.Exports[name] = value
+		}
+	}
+
+	e.modules[filename] = module
+	return module
+}
+
+// This method finds the file an import path refers to
+// Paths are tried relative to the importing file first, then against each directory in the search path.
+// Each candidate is checked against the fs-read capability before the filesystem is consulted, so a path which may not
+// be read is reported as denied whether or not it exists.
+func (e *Evaluator) resolveModule(original string) (string, *object.Error) {
+	path := original
+	if filepath.Ext(path) == "" {
+		path += ModuleExtension
+	}
+
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		directory := "."
+		if len(e.loading) > 0 {
+			directory = filepath.Dir(e.loading[len(e.loading)-1])
+		}
+		candidates = append(candidates, filepath.Join(directory, path))
+
+		if !strings.HasPrefix(path, "./") && !strings.HasPrefix(path, "../") {
+			for _, directory := range e.SearchPath {
+				candidates = append(candidates, filepath.Join(directory, path))
+			}
+		}
+	}
+
+	denied := false
+	for _, candidate := range candidates {
+		readable, ok := e.Capabilities.readablePath(candidate)
+		if !ok {
+			denied = true
+			continue
+		}
+
+		if info, err := os.Stat(readable); err == nil && !info.IsDir() {
+			absolute, err := filepath.Abs(readable)
+			if err != nil {
+				break
+			}
+			return absolute, nil
+		}
+	}
+
+	if denied {
+		return "", permissionDenied("%s is not granted for %s", FS_READ, original)
+	}
+	return "", newError("Module Not Found: %s", original)
+}
+
+// This helper function reads and parses a module file, returning an error object holding the first problem if either step fails
+func readModule(filename string) (*ast.Program, *object.Error) {
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, newError("Import Error: %s", err)
+	}
+
+	parse := parser.New(lexer.New(string(source)))
+	program := parse.ParseProgram()
+	if len(parse.Errors()) != 0 {
+		return nil, newError("Import Error: %s: %s", filepath.Base(filename), parse.Errors()[0])
+	}
+
+	return program, nil
+}
+
+// This helper function lists the names declared by the top level export statements of a program
+func exportedNames(program *ast.Program) []string {
+	var names []string
+
+	for _, statement := range program.Statements {
+		export, ok := statement.(*ast.ExportStatement)
+		if !ok {
+			continue
+		}
+
+		switch declaration := export.Statement.(type) {
+		case *ast.LetStatement:
+			if declaration.Pattern != nil {
+				names = append(names, patternNames(declaration.Pattern)...)
+			} else {
+				names = append(names, declaration.Name.Value)
+			}
+		case *ast.StructStatement:
+			names = append(names, declaration.Name.Value)
+		case *ast.ClassStatement:
+			names = append(names, declaration.Name.Value)
+		}
+	}
+
+	return names
+}
+
+// This helper function lists the names a pattern binds
+func patternNames(pattern ast.Pattern) []string {
+	switch pattern := pattern.(type) {
+	case *ast.IdentifierPattern:
+		return []string{pattern.Name.Value}
+	case *ast.TuplePattern:
+		var names []string
+		for _, element := range pattern.Elements {
+			names = append(names, patternNames(element)...)
+		}
+		return names
+	}
+	return nil
+}
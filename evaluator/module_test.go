@@ -0,0 +1,163 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// This helper function writes each file into dir, creating any directories needed
+func writeModules(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// This helper function creates an Evaluator which may import modules from dir
+func newModuleEvaluator(dir string) *Evaluator {
+	eval := New()
+	eval.Capabilities.AllowRead(dir)
+	return eval
+}
+
+func TestModuleImports(t *testing.T) {
+	dir := t.TempDir()
+	writeModules(t, dir, map[string]string{
+		"main.monkey": `import "lib/math" as m
+import "./lib/counter"
+let (lo, hi) = m.bounds
+m.square(m.offset) + counter.count + lo + hi`,
+		"lib/math.monkey": `import "helpers" as h
+export let square = fn(x) { h.mul(x, x) }
+export let offset = 3
+export let (low, high) = (1, 2)
+export let bounds = (low, high)
+let hidden = 10`,
+		"lib/helpers.monkey": `export let mul = fn(a, b) { a * b }`,
+		"lib/counter.monkey": `export let count = 100`,
+	})
+
+	evaluated := newModuleEvaluator(dir).EvaluateFile(filepath.Join(dir, "main.monkey"), object.NewEnvironment())
+	testIntegerObject(t, evaluated, 112)
+}
+
+func TestModuleSearchPath(t *testing.T) {
+	dir := t.TempDir()
+	writeModules(t, dir, map[string]string{
+		"app/main.monkey":        `import "strings/pad" as p; p.width`,
+		"std/strings/pad.monkey": `export let width = 8`,
+	})
+
+	eval := newModuleEvaluator(dir)
+	eval.SearchPath = []string{filepath.Join(dir, "std")}
+
+	evaluated := eval.EvaluateFile(filepath.Join(dir, "app", "main.monkey"), object.NewEnvironment())
+	testIntegerObject(t, evaluated, 8)
+}
+
+func TestModuleEvaluatedOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeModules(t, dir, map[string]string{
+		"main.monkey": `import "a"; import "b"; a.shared == b.shared`,
+		"a.monkey":    `import "shared" as s; export let shared = s.value`,
+		"b.monkey":    `import "shared" as s; export let shared = s.value`,
+		"shared.monkey": `struct Box { n }
+export let value = Box { n: 1 }`,
+	})
+
+	eval := newModuleEvaluator(dir)
+	evaluated := eval.EvaluateFile(filepath.Join(dir, "main.monkey"), object.NewEnvironment())
+	testBooleanObject(t, evaluated, true)
+
+	if len(eval.modules) != 3 {
+		t.Errorf("Incorrect number of cached modules! Expected 3 but received '%d'", len(eval.modules))
+	}
+}
+
+func TestModuleErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeModules(t, dir, map[string]string{
+		"a.monkey":       `import "b"; export let x = 1`,
+		"b.monkey":       `import "c"; export let y = 2`,
+		"c.monkey":       `import "a"; export let z = 3`,
+		"lib.monkey":     `export let f = 1; let g = 2`,
+		"broken.monkey":  `let = 1`,
+		"missing.monkey": `import "nowhere"`,
+		"private.monkey": `import "lib"; lib.g`,
+		"bad.monkey":     `import "broken"`,
+	})
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"a.monkey", "Import Cycle: a.monkey -> b.monkey -> c.monkey -> a.monkey"},
+		{"missing.monkey", "Module Not Found: nowhere"},
+		{"private.monkey", "Unknown Export: lib has no export 'g'"},
+		{"bad.monkey", "Import Error: broken.monkey: Expected next token to be 'IDENTIFIERS', instead received '='!"},
+	}
+
+	for _, tt := range tests {
+		evaluated := newModuleEvaluator(dir).EvaluateFile(filepath.Join(dir, tt.file), object.NewEnvironment())
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
+
+func TestModuleImportPermissions(t *testing.T) {
+	dir := t.TempDir()
+	writeModules(t, dir, map[string]string{
+		"main.monkey":          `import "secret" as s; s.token`,
+		"public/main.monkey":   `import "../secret" as s; s.token`,
+		"public/probe.monkey":  `import "../missing" as m; m`,
+		"secret.monkey":        `export let token = "hunter2"`,
+		"public/helper.monkey": `export let x = 1`,
+	})
+
+	readable := NewCapabilities()
+	readable.AllowRead(filepath.Join(dir, "public"))
+
+	tests := []struct {
+		file         string
+		capabilities *Capabilities
+		expected     string
+	}{
+		{"main.monkey", NewCapabilities(), "Permission Denied: `import` requires the fs-read capability"},
+		{"public/main.monkey", readable, "Permission Denied: fs-read is not granted for ../secret"},
+		{"public/probe.monkey", readable, "Permission Denied: fs-read is not granted for ../missing"},
+	}
+
+	for _, tt := range tests {
+		eval := New()
+		eval.Capabilities = tt.capabilities
+		evaluated := eval.EvaluateFile(filepath.Join(dir, tt.file), object.NewEnvironment())
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Kind != object.PERMISSION_DENIED_ERROR || errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error! Expected '%s' but receieved %+v", tt.expected, errorObject)
+		}
+	}
+}
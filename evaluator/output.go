@@ -0,0 +1,209 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// the largest width or precision a format verb may have
+const maxFormatWidth = 1 << 16
+
+func init() {
+	builtins["format"] = &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			formatted, err := formatArguments(ctx, "format", args)
+			if err != nil {
+				return err
+			}
+			return &object.String{Value: formatted}
+		},
+	}
+}
+
+// The builtins which print to the Output of the Evaluator calling them. They need the stdout capability.
+//   - puts writes each of its arguments on its own line
+//   - print writes its arguments separated by spaces, without a newline
+//   - printf writes its arguments formatted like format, without a newline
+var outputBuiltins = map[string]*object.BuiltIn{
+	"puts": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			var out strings.Builder
+			for _, arg := range args {
+				out.WriteString(arg.Inspect())
+				out.WriteString("\n")
+			}
+			return write(ctx, "puts", out.String())
+		},
+	},
+	"print": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			values := make([]string, len(args))
+			for index, arg := range args {
+				values[index] = arg.Inspect()
+			}
+			return write(ctx, "print", strings.Join(values, " "))
+		},
+	},
+	"printf": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			formatted, err := formatArguments(ctx, "printf", args)
+			if err != nil {
+				return err
+			}
+			return write(ctx, "printf", formatted)
+		},
+	},
+}
+
+// This helper function writes text to the Output of the Evaluator calling a builtin. Writes are serialised so that the
+// output of spawned tasks is not interleaved part way through a line.
+func write(ctx context.Context, builtin string, text string) object.Object {
+	e, err := caller(ctx, builtin, STDOUT)
+	if err != nil {
+		return err
+	}
+
+	e.outputLock.Lock()
+	defer e.outputLock.Unlock()
+
+	if _, err := io.WriteString(e.Output, text); err != nil {
+		return newError("Output Error: %s", err)
+	}
+	return NULL
+}
+
+// This helper function checks the arguments of format and printf, which are a format string followed by the values
+// it refers to, and returns the formatted text
+func formatArguments(ctx context.Context, name string, args []object.Object) (string, *object.Error) {
+	if len(args) == 0 {
+		return "", newError("Incorrect number of arguments detected! Needed at least 1 but instead received 0!")
+	}
+
+	format, ok := args[0].(*object.String)
+	if !ok {
+		return "", newError("Argument to `%s` is not supported! Instead received an %s!", name, args[0].Type())
+	}
+
+	return formatValues(ctx, format.Value, args[1:])
+}
+
+// This function formats values according to format, in which each verb is replaced by the next value:
+//
+//	%v  any value, as it is displayed by puts
+//	%s  any value, like %v
+//	%q  a string, quoted with escapes
+//	%d  an integer in decimal, and %b, %o, %x and %X in binary, octal and hexadecimal
+//	%c  an integer, as the character with that code point
+//	%t  a boolean
+//	%T  the type of any value
+//	%%  a literal percent sign, which uses no value
+//
+// Verbs take the flags, width and precision of Go's fmt package, such as %-8s or %05d.
+// The text is checked against the string length limit of the evaluation calling it as each value is formatted.
+func formatValues(ctx context.Context, format string, values []object.Object) (string, *object.Error) {
+	var out strings.Builder
+	next := 0
+
+	for index := 0; index < len(format); index++ {
+		if format[index] != '%' {
+			out.WriteByte(format[index])
+			continue
+		}
+
+		start := index
+		index++
+		for index < len(format) && strings.IndexByte("+-# 0", format[index]) >= 0 {
+			index++
+		}
+		var width, precision int
+		width, index = formatNumber(format, index)
+		if index < len(format) && format[index] == '.' {
+			precision, index = formatNumber(format, index+1)
+		}
+
+		if index >= len(format) {
+			return "", newError("Format Error: incomplete verb '%s' at the end of the format", format[start:])
+		}
+		if width > maxFormatWidth || precision > maxFormatWidth {
+			return "", newError("Format Error: the width and precision of '%s' cannot exceed %d", format[start:index+1], maxFormatWidth)
+		}
+
+		spec, verb := format[start:index+1], format[index]
+		if verb == '%' {
+			out.WriteByte('%')
+			continue
+		}
+
+		if next >= len(values) {
+			return "", newError("Format Error: missing value for '%s'", spec)
+		}
+		value := values[next]
+		next++
+
+		if err := CheckStringLength(ctx, out.Len()+width); err != nil {
+			return "", err
+		}
+		formatted, err := formatValue(spec, verb, value)
+		if err != nil {
+			return "", err
+		}
+		if err := CheckStringLength(ctx, out.Len()+len(formatted)); err != nil {
+			return "", err
+		}
+		out.WriteString(formatted)
+	}
+
+	if next < len(values) {
+		return "", newError("Format Error: %d values were not used by the format", len(values)-next)
+	}
+
+	return out.String(), nil
+}
+
+// This helper function reads the number starting at index of format, returning it and the index following it
+func formatNumber(format string, index int) (int, int) {
+	start := index
+	for index < len(format) && format[index] >= '0' && format[index] <= '9' {
+		index++
+	}
+
+	number, err := strconv.Atoi(format[start:index])
+	if err != nil && index > start {
+		return maxFormatWidth + 1, index
+	}
+	return number, index
+}
+
+// This helper function formats a single value for a verb. spec is the whole verb, including its flags, width and precision.
+func formatValue(spec string, verb byte, value object.Object) (string, *object.Error) {
+	// The verb is swapped for one which Go formats the converted value with
+	goSpec := func(goVerb byte) string { return spec[:len(spec)-1] + string(goVerb) }
+
+	switch verb {
+	case 'v', 's':
+		return fmt.Sprintf(goSpec('s'), value.Inspect()), nil
+	case 'T':
+		return fmt.Sprintf(goSpec('s'), string(value.Type())), nil
+	case 'q':
+		if str, ok := value.(*object.String); ok {
+			return fmt.Sprintf(spec, str.Value), nil
+		}
+	case 'd', 'b', 'o', 'x', 'X', 'c':
+		if integer, ok := value.(*object.Integer); ok {
+			return fmt.Sprintf(spec, integer.Value), nil
+		}
+	case 't':
+		if boolean, ok := value.(*object.Boolean); ok {
+			return fmt.Sprintf(spec, boolean.Value), nil
+		}
+	default:
+		return "", newError("Format Error: unknown verb '%s'", spec)
+	}
+
+	return "", newError("Format Error: '%s' cannot format a value of type %s", spec, value.Type())
+}
@@ -0,0 +1,123 @@
+package evaluator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+// This helper function evaluates input with its output captured, returning the result and what was written
+func testEvaluateOutput(input string) (object.Object, string) {
+	var out bytes.Buffer
+
+	eval := New()
+	eval.Capabilities = NewCapabilities(STDOUT)
+	eval.Output = &out
+
+	evaluated := eval.Evaluate(parser.New(lexer.New(input)).ParseProgram(), object.NewEnvironment())
+	return evaluated, out.String()
+}
+
+func TestOutputBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`puts("hello")`, "hello\n"},
+		{`puts(1, true, null)`, "1\ntrue\nnull\n"},
+		{`puts()`, ""},
+		{`print("a", 1, (2, 3))`, "a 1 (2, 3)"},
+		{`print("a"); print("b")`, "ab"},
+		{`printf("%s has %d items", "cart", 3); puts("")`, "cart has 3 items\n"},
+		{`struct Point { x, y }; puts(Point { x: 1, y: 2 })`, "Point{x: 1, y: 2}\n"},
+		{`let done = channel()
+spawn fn() { puts("from a task"); send(done, true) }
+receive(done)
+puts("after")`, "from a task\nafter\n"},
+	}
+
+	for _, tt := range tests {
+		evaluated, output := testEvaluateOutput(tt.input)
+
+		if errorObject, ok := evaluated.(*object.Error); ok {
+			t.Errorf("Evaluation of %q failed: %s", tt.input, errorObject.Message)
+			continue
+		}
+
+		if output != tt.expected {
+			t.Errorf("Incorrect output for %q! Expected %q but instead received %q", tt.input, tt.expected, output)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`format("plain")`, "plain"},
+		{`format("%d + %d = %d", 1, 2, 3)`, "1 + 2 = 3"},
+		{`format("%s and %v", "str", "value")`, "str and value"},
+		{"format(\"%q\", \"a\tb\")", `"a\tb"`},
+		{`format("%5d|%-5d|%05d", 42, 42, 42)`, "   42|42   |00042"},
+		{`format("%b %o %x %X", 10, 8, 255, 255)`, "1010 10 ff FF"},
+		{`format("%c%c", 72, 105)`, "Hi"},
+		{`format("%t %t", true, false)`, "true false"},
+		{`format("%v %v", null, (1, "a"))`, "null (1, a)"},
+		{`format("%T %T %T %T", 1, "a", null, fn(x) { x })`, "INTEGER STRING NULL FUNCTION"},
+		{`struct Point { x, y }; format("%v", Point { x: 1, y: 2 })`, "Point{x: 1, y: 2}"},
+		{`class Dog { init(self, name) { self.name = name } }; format("%s is %T", Dog("rex"), Dog("rex"))`, "Dog{name: rex} is INSTANCE"},
+		{`format("%-6s|%6s|%.2s", "ab", "cd", "efgh")`, "ab    |    cd|ef"},
+		{`format("100%%")`, "100%"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("Object is not of type String! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if str.Value != tt.expected {
+			t.Errorf("Incorrect result for %q! Expected %q but instead received %q", tt.input, tt.expected, str.Value)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`format()`, "Incorrect number of arguments detected! Needed at least 1 but instead received 0!"},
+		{`format(1)`, "Argument to `format` is not supported! Instead received an INTEGER!"},
+		{`format("%d")`, "Format Error: missing value for '%d'"},
+		{`format("%d", "a")`, "Format Error: '%d' cannot format a value of type STRING"},
+		{`format("%t", 1)`, "Format Error: '%t' cannot format a value of type INTEGER"},
+		{`format("%q", 1)`, "Format Error: '%q' cannot format a value of type INTEGER"},
+		{`format("%z", 1)`, "Format Error: unknown verb '%z'"},
+		{`format("value: %5")`, "Format Error: incomplete verb '%5' at the end of the format"},
+		{`format("%d", 1, 2, 3)`, "Format Error: 2 values were not used by the format"},
+		{`format("%100000d", 1)`, "Format Error: the width and precision of '%100000d' cannot exceed 65536"},
+		{`printf(1)`, "Argument to `printf` is not supported! Instead received an INTEGER!"},
+	}
+
+	for _, tt := range tests {
+		evaluated, _ := testEvaluateOutput(tt.input)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
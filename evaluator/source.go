@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source supplies the time and the random numbers used by builtins.
+// Nothing else the evaluator produces depends on the environment it runs in: values such as structs, instances and
+// modules are always listed in the order their fields and exports were declared, never in Go's map iteration order.
+// Replacing the Source with a SeededSource therefore makes every run of a program reproducible.
+type Source interface {
+	// Now returns the current time
+	Now() time.Time
+	// Int63n returns a random number in [0, n)
+	Int63n(n int64) int64
+}
+
+// the Source used by default, which reads the system clock and the global random number generator
+type systemSource struct{}
+
+func (systemSource) Now() time.Time       { return time.Now() }
+func (systemSource) Int63n(n int64) int64 { return rand.Int63n(n) }
+
+// SeededSource is a deterministic Source. Its random numbers are generated from a seed and its clock starts at a fixed
+// time and moves forward by one millisecond each time it is read, so two sources with the same seed behave identically.
+// It is safe for concurrent use, although runs whose spawned tasks share it are only reproducible if the tasks
+// read it in a fixed order.
+type SeededSource struct {
+	mu     sync.Mutex
+	seed   int64
+	random *rand.Rand
+	now    time.Time
+}
+
+// the time at which every SeededSource's clock starts
+var seededEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// This function creates a SeededSource from seed
+func NewSeededSource(seed int64) *SeededSource {
+	s := &SeededSource{seed: seed}
+	s.Reset()
+	return s
+}
+
+// This method returns the seed the source was created with
+func (s *SeededSource) Seed() int64 {
+	return s.seed
+}
+
+// This method returns the source to the state it was created in, so that a run can be replayed from the start
+func (s *SeededSource) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.random = rand.New(rand.NewSource(s.seed))
+	s.now = seededEpoch
+}
+
+func (s *SeededSource) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = s.now.Add(time.Millisecond)
+	return s.now
+}
+
+func (s *SeededSource) Int63n(n int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.random.Int63n(n)
+}
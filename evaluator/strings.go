@@ -0,0 +1,199 @@
+package evaluator
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// the longest string which repeat will build
+const maxRepeatLength = 1 << 30
+
+// The string methods of the standard library, added to those declared with the other methods in builtin.go.
+// Positions are counted in characters rather than bytes, so "héllo".indexOf("l") is 2.
+var stringMethods = map[string]object.BuiltInFunction{
+	"trim": func(args ...object.Object) object.Object {
+		if err := checkMethodArguments("trim", args, 0); err != nil {
+			return err
+		}
+		return &object.String{Value: strings.TrimSpace(args[0].(*object.String).Value)}
+	},
+	"trimLeft": func(args ...object.Object) object.Object {
+		if err := checkMethodArguments("trimLeft", args, 0); err != nil {
+			return err
+		}
+		return &object.String{Value: strings.TrimLeftFunc(args[0].(*object.String).Value, unicode.IsSpace)}
+	},
+	"trimRight": func(args ...object.Object) object.Object {
+		if err := checkMethodArguments("trimRight", args, 0); err != nil {
+			return err
+		}
+		return &object.String{Value: strings.TrimRightFunc(args[0].(*object.String).Value, unicode.IsSpace)}
+	},
+	"contains": func(args ...object.Object) object.Object {
+		str, substr, err := stringMethodArguments("contains", args)
+		if err != nil {
+			return err
+		}
+		return nativeBoolToBooleanObject(strings.Contains(str, substr))
+	},
+	"startsWith": func(args ...object.Object) object.Object {
+		str, prefix, err := stringMethodArguments("startsWith", args)
+		if err != nil {
+			return err
+		}
+		return nativeBoolToBooleanObject(strings.HasPrefix(str, prefix))
+	},
+	"endsWith": func(args ...object.Object) object.Object {
+		str, suffix, err := stringMethodArguments("endsWith", args)
+		if err != nil {
+			return err
+		}
+		return nativeBoolToBooleanObject(strings.HasSuffix(str, suffix))
+	},
+	"indexOf": func(args ...object.Object) object.Object {
+		str, substr, err := stringMethodArguments("indexOf", args)
+		if err != nil {
+			return err
+		}
+
+		index := strings.Index(str, substr)
+		if index < 0 {
+			return &object.Integer{Value: -1}
+		}
+		return &object.Integer{Value: int64(utf8.RuneCountInString(str[:index]))}
+	},
+	"substring": func(args ...object.Object) object.Object {
+		if len(args) != 2 && len(args) != 3 {
+			return newError("Incorrect number of arguments to `substring` detected! Only needed 1 or 2 but instead received %d!", len(args)-1)
+		}
+
+		runes := []rune(args[0].(*object.String).Value)
+
+		bounds := []int64{0, int64(len(runes))}
+		for index, arg := range args[1:] {
+			integer, ok := arg.(*object.Integer)
+			if !ok {
+				return newError("Argument to `substring` is not supported! Instead received an %s!", arg.Type())
+			}
+			bounds[index] = integer.Value
+		}
+
+		start, end := bounds[0], bounds[1]
+		if start < 0 || start > end || end > int64(len(runes)) {
+			return newError("Invalid Range: %d to %d is outside a string of length %d", start, end, len(runes))
+		}
+
+		return &object.String{Value: string(runes[start:end])}
+	},
+}
+
+// The string methods which build a string whose length depends on their arguments. They receive the context of the
+// calling evaluation, and check the result against its string length limit before building it.
+var stringContextMethods = map[string]object.ContextBuiltInFunction{
+	"replace": func(ctx context.Context, args ...object.Object) object.Object {
+		if err := checkMethodArguments("replace", args, 2); err != nil {
+			return err
+		}
+
+		old, ok := args[1].(*object.String)
+		if !ok {
+			return newError("Argument to `replace` is not supported! Instead received an %s!", args[1].Type())
+		}
+		replacement, ok := args[2].(*object.String)
+		if !ok {
+			return newError("Argument to `replace` is not supported! Instead received an %s!", args[2].Type())
+		}
+
+		str := args[0].(*object.String).Value
+		count := strings.Count(str, old.Value)
+		if err := CheckStringLength(ctx, len(str)+count*(len(replacement.Value)-len(old.Value))); err != nil {
+			return err
+		}
+
+		return &object.String{Value: strings.ReplaceAll(str, old.Value, replacement.Value)}
+	},
+	"repeat": func(ctx context.Context, args ...object.Object) object.Object {
+		if err := checkMethodArguments("repeat", args, 1); err != nil {
+			return err
+		}
+
+		count, ok := args[1].(*object.Integer)
+		if !ok {
+			return newError("Argument to `repeat` is not supported! Instead received an %s!", args[1].Type())
+		}
+
+		str := args[0].(*object.String).Value
+		if count.Value < 0 {
+			return newError("Argument to `repeat` must not be negative! Instead received %d!", count.Value)
+		}
+		if len(str) > 0 && count.Value > maxRepeatLength/int64(len(str)) {
+			return newError("Argument to `repeat` is too large! The result would be longer than %d bytes!", maxRepeatLength)
+		}
+		if err := CheckStringLength(ctx, len(str)*int(count.Value)); err != nil {
+			return err
+		}
+
+		return &object.String{Value: strings.Repeat(str, int(count.Value))}
+	},
+	"join": func(ctx context.Context, args ...object.Object) object.Object {
+		separator := args[0].(*object.String).Value
+
+		parts := args[1:]
+		if len(parts) == 1 {
+			if tuple, ok := parts[0].(*object.Tuple); ok {
+				parts = tuple.Elements
+			}
+		}
+
+		values := make([]string, len(parts))
+		length := 0
+		for index, part := range parts {
+			str, ok := part.(*object.String)
+			if !ok {
+				return newError("Argument to `join` is not supported! Instead received an %s!", part.Type())
+			}
+			values[index] = str.Value
+			length += len(str.Value)
+		}
+
+		if len(values) > 1 {
+			length += len(separator) * (len(values) - 1)
+		}
+		if err := CheckStringLength(ctx, length); err != nil {
+			return err
+		}
+
+		return &object.String{Value: strings.Join(values, separator)}
+	},
+}
+
+func init() {
+	for name, function := range stringMethods {
+		methods[object.STRING_OBJ][name] = &object.BuiltIn{Function: function}
+	}
+	for name, function := range stringContextMethods {
+		methods[object.STRING_OBJ][name] = &object.BuiltIn{ContextFunction: function}
+	}
+
+	// Every string method is also a builtin which takes the string as its first argument, such as upper("abc")
+	// or join(", ", "a", "b")
+	registerMethodFunctions(object.STRING_OBJ)
+}
+
+// This helper function checks the arguments of a string method which takes a single string, returning both strings
+func stringMethodArguments(name string, args []object.Object) (string, string, *object.Error) {
+	if err := checkMethodArguments(name, args, 1); err != nil {
+		return "", "", err
+	}
+
+	argument, ok := args[1].(*object.String)
+	if !ok {
+		return "", "", newError("Argument to `%s` is not supported! Instead received an %s!", name, args[1].Type())
+	}
+
+	return args[0].(*object.String).Value, argument.Value, nil
+}
@@ -0,0 +1,138 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+func TestStringMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"  padded  ".trim()`, "padded"},
+		{`"  padded  ".trimLeft()`, "padded  "},
+		{`"  padded  ".trimRight()`, "  padded"},
+		{`"monkey".contains("key")`, true},
+		{`"monkey".contains("ape")`, false},
+		{`"monkey".startsWith("mon")`, true},
+		{`"monkey".endsWith("mon")`, false},
+		{`"monkey".indexOf("key")`, 3},
+		{`"monkey".indexOf("ape")`, -1},
+		{`"héllo".indexOf("l")`, 2},
+		{`"a-b-c".replace("-", "+")`, "a+b+c"},
+		{`"ab".repeat(3)`, "ababab"},
+		{`"ab".repeat(0)`, ""},
+		{`"héllo".substring(1, 3)`, "él"},
+		{`"héllo".substring(2)`, "llo"},
+		{`"héllo".substring(5)`, ""},
+		{`", ".join("a", "b", "c")`, "a, b, c"},
+		{`"-".join("a,b,c".split(","))`, "a-b-c"},
+		{`"-".join()`, ""},
+		{`"  Mixed Case ".trim().lower().replace(" ", "_")`, "mixed_case"},
+	}
+
+	for _, tt := range tests {
+		testStringResult(t, tt.input, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestStringFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`split("a,b", ",")`, "(a, b)"},
+		{`join(", ", "a", "b", "c")`, "a, b, c"},
+		{`join("", ("x", "y"))`, "xy"},
+		{`upper("abc")`, "ABC"},
+		{`lower("ABC")`, "abc"},
+		{`trim("  x  ")`, "x"},
+		{`contains("monkey", "key")`, true},
+		{`startsWith("monkey", "mon")`, true},
+		{`endsWith("monkey", "key")`, true},
+		{`indexOf("monkey", "n")`, 2},
+		{`replace("aaa", "a", "b")`, "bbb"},
+		{`repeat("-", 3)`, "---"},
+		{`substring("monkey", 0, 3)`, "mon"},
+		{`"a b c" |> (s => split(s, " "))`, "(a, b, c)"},
+		{`len("four")`, 4},
+	}
+
+	for _, tt := range tests {
+		testStringResult(t, tt.input, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestStringComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"apple" < "banana"`, true},
+		{`"apple" > "banana"`, false},
+		{`"b" > "abc"`, true},
+		{`"abc" < "abc"`, false},
+		{`"" < "a"`, true},
+		{`"Z" < "a"`, true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEvaluate(tt.input), tt.expected)
+	}
+}
+
+func TestStringErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc".contains(1)`, "Argument to `contains` is not supported! Instead received an INTEGER!"},
+		{`"abc".startsWith()`, "Incorrect number of arguments to `startsWith` detected! Only needed 1 but instead received 0!"},
+		{`"abc".replace("a")`, "Incorrect number of arguments to `replace` detected! Only needed 2 but instead received 1!"},
+		{`"abc".replace("a", 1)`, "Argument to `replace` is not supported! Instead received an INTEGER!"},
+		{`"abc".repeat(-1)`, "Argument to `repeat` must not be negative! Instead received -1!"},
+		{`"abc".repeat(1000000000)`, "Argument to `repeat` is too large! The result would be longer than 1073741824 bytes!"},
+		{`"abc".substring(2, 1)`, "Invalid Range: 2 to 1 is outside a string of length 3"},
+		{`"abc".substring(0, 4)`, "Invalid Range: 0 to 4 is outside a string of length 3"},
+		{`"abc".substring(-1)`, "Invalid Range: -1 to 3 is outside a string of length 3"},
+		{`"abc".substring()`, "Incorrect number of arguments to `substring` detected! Only needed 1 or 2 but instead received 0!"},
+		{`"abc".substring("a")`, "Argument to `substring` is not supported! Instead received an STRING!"},
+		{`", ".join("a", 1)`, "Argument to `join` is not supported! Instead received an INTEGER!"},
+		{`upper(1)`, "Argument to `upper` is not supported! Instead received an INTEGER!"},
+		{`join()`, "Incorrect number of arguments detected! Needed at least 1 but instead received 0!"},
+		{`"a" * "b"`, "Unknown Operator: STRING * STRING"},
+		{`"a" < 1`, "Type Mismatch: STRING < INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(tt.input)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error message! Expected '%s' but receieved '%s'", tt.expected, errorObject.Message)
+		}
+	}
+}
+
+// This helper function checks a result which is expected to be a string, an integer or a boolean
+func testStringResult(t *testing.T, input string, evaluated object.Object, expected interface{}) {
+	t.Helper()
+
+	switch expected := expected.(type) {
+	case int:
+		testIntegerObject(t, evaluated, int64(expected))
+	case bool:
+		testBooleanObject(t, evaluated, expected)
+	case string:
+		if evaluated == nil || evaluated.Inspect() != expected {
+			t.Errorf("Incorrect result for %q! Expected %q but instead received %+v", input, expected, evaluated)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package evaluator
+
+import (
+	"context"
+	"os"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// The builtins which reach outside the interpreter, such as reading files and the clock. They act for the Evaluator
+// calling them, which they find in the context they receive, and check its capabilities each time they are called.
+var systemBuiltins = map[string]*object.BuiltIn{
+	"readFile": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			path, err := stringArgument("readFile", args)
+			if err != nil {
+				return err
+			}
+
+			e, err := caller(ctx, "readFile", FS_READ)
+			if err != nil {
+				return err
+			}
+			readable, ok := e.Capabilities.readablePath(path)
+			if !ok {
+				return permissionDenied("%s is not granted for %s", FS_READ, path)
+			}
+
+			contents, readErr := os.ReadFile(readable)
+			if readErr != nil {
+				return newError("File Error: %s", readErr)
+			}
+			return &object.String{Value: string(contents)}
+		},
+	},
+	"writeFile": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Incorrect number of arguments detected! Only needed 2 but instead received %d!", len(args))
+			}
+
+			path, ok := args[0].(*object.String)
+			if !ok {
+				return newError("Argument to `writeFile` is not supported! Instead received an %s!", args[0].Type())
+			}
+			contents, ok := args[1].(*object.String)
+			if !ok {
+				return newError("Argument to `writeFile` is not supported! Instead received an %s!", args[1].Type())
+			}
+
+			if _, err := caller(ctx, "writeFile", FS_WRITE); err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(path.Value, []byte(contents.Value), 0o644); err != nil {
+				return newError("File Error: %s", err)
+			}
+			return NULL
+		},
+	},
+	"getenv": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			name, err := stringArgument("getenv", args)
+			if err != nil {
+				return err
+			}
+
+			if _, err := caller(ctx, "getenv", ENV); err != nil {
+				return err
+			}
+
+			if value, ok := os.LookupEnv(name); ok {
+				return &object.String{Value: value}
+			}
+			return NULL
+		},
+	},
+	"time": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Incorrect number of arguments detected! Only needed 0 but instead received %d!", len(args))
+			}
+
+			e, err := caller(ctx, "time", TIME)
+			if err != nil {
+				return err
+			}
+
+			return &object.Integer{Value: e.Source.Now().UnixMilli()}
+		},
+	},
+	"random": &object.BuiltIn{
+		ContextFunction: func(ctx context.Context, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+			}
+
+			limit, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("Argument to `random` is not supported! Instead received an %s!", args[0].Type())
+			}
+			if limit.Value <= 0 {
+				return newError("Argument to `random` must be positive! Instead received %d!", limit.Value)
+			}
+
+			e, err := caller(ctx, "random", RANDOM)
+			if err != nil {
+				return err
+			}
+
+			return &object.Integer{Value: e.Source.Int63n(limit.Value)}
+		},
+	},
+}
+
+func init() {
+	RegisterSystemBuiltins(&Builtins{functions: builtins, methods: methods})
+}
+
+// This function registers the builtins which reach outside the interpreter, including the output builtins.
+// They are part of the default builtins, and embedding code which starts from an empty registry can call this to add them.
+func RegisterSystemBuiltins(b *Builtins) {
+	for name, builtin := range systemBuiltins {
+		b.functions[name] = builtin
+	}
+	for name, builtin := range outputBuiltins {
+		b.functions[name] = builtin
+	}
+}
+
+// the key under which the context passed to builtins holds the Evaluator calling them
+type evaluatorKey struct{}
+
+// This method returns the context passed to builtins, which carries e along with its cancellation
+func (e *Evaluator) builtinContext() context.Context {
+	return context.WithValue(e.ctx, evaluatorKey{}, e)
+}
+
+// This function returns the Evaluator calling a builtin from the context the builtin received.
+// Registries can be shared between Evaluators, so builtins use it rather than an Evaluator they were registered by.
+func FromContext(ctx context.Context) (*Evaluator, bool) {
+	e, ok := ctx.Value(evaluatorKey{}).(*Evaluator)
+	return e, ok
+}
+
+// This helper function returns the Evaluator calling a builtin, after checking that it grants the capability the builtin needs.
+// A builtin called from outside an Evaluator is granted nothing.
+func caller(ctx context.Context, builtin string, capability Capability) (*Evaluator, *object.Error) {
+	e, ok := FromContext(ctx)
+	if !ok {
+		return nil, permissionDenied("`%s` requires the %s capability", builtin, capability)
+	}
+
+	if err := e.require(builtin, capability); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// This helper function returns the single string argument of a builtin
+func stringArgument(name string, args []object.Object) (string, *object.Error) {
+	if len(args) != 1 {
+		return "", newError("Incorrect number of arguments detected! Only needed 1 but instead received %d!", len(args))
+	}
+
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return "", newError("Argument to `%s` is not supported! Instead received an %s!", name, args[0].Type())
+	}
+
+	return str.Value, nil
+}
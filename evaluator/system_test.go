@@ -0,0 +1,142 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+// This helper function evaluates input with the given capabilities
+func testEvaluateWithCapabilities(input string, capabilities *Capabilities) object.Object {
+	eval := New()
+	eval.Capabilities = capabilities
+	return eval.Evaluate(parser.New(lexer.New(input)).ParseProgram(), object.NewEnvironment())
+}
+
+func TestSystemBuiltins(t *testing.T) {
+	dir := t.TempDir()
+	public := filepath.Join(dir, "public")
+	os.MkdirAll(public, 0o755)
+	os.WriteFile(filepath.Join(public, "data.txt"), []byte("hello"), 0o644)
+	os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o644)
+	t.Setenv("MONKEY_TEST_VALUE", "banana")
+
+	readable := NewCapabilities()
+	readable.AllowRead(public)
+
+	tests := []struct {
+		input        string
+		capabilities *Capabilities
+		expected     interface{}
+	}{
+		{`readFile("` + filepath.Join(public, "data.txt") + `")`, readable, "hello"},
+		{`readFile("` + filepath.Join(dir, "secret.txt") + `")`, NewCapabilities(FS_READ), "secret"},
+		{`writeFile("` + filepath.Join(dir, "out.txt") + `", "written"); readFile("` + filepath.Join(dir, "out.txt") + `")`, AllCapabilities(), "written"},
+		{`getenv("MONKEY_TEST_VALUE")`, NewCapabilities(ENV), "banana"},
+		{`getenv("MONKEY_TEST_MISSING")`, NewCapabilities(ENV), nil},
+		{`time() > 0`, NewCapabilities(TIME), true},
+		{`random(1)`, NewCapabilities(RANDOM), 0},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluateWithCapabilities(tt.input, tt.capabilities)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok || str.Value != expected {
+				t.Errorf("Incorrect result for %q! Expected %q but instead received %+v", tt.input, expected, evaluated)
+			}
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestPermissionDenied(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o644)
+	os.MkdirAll(filepath.Join(dir, "public"), 0o755)
+	os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(dir, "public", "link.txt"))
+	os.MkdirAll(filepath.Join(dir, "publicity"), 0o755)
+	os.WriteFile(filepath.Join(dir, "publicity", "plan.txt"), []byte("plan"), 0o644)
+
+	readable := NewCapabilities()
+	readable.AllowRead(filepath.Join(dir, "public"))
+
+	revoked := AllCapabilities()
+	revoked.Revoke(TIME)
+
+	tests := []struct {
+		input        string
+		capabilities *Capabilities
+		expected     string
+	}{
+		{`readFile("secret.txt")`, NewCapabilities(), "Permission Denied: `readFile` requires the fs-read capability"},
+		{`readFile("` + filepath.Join(dir, "secret.txt") + `")`, readable, "Permission Denied: fs-read is not granted for " + filepath.Join(dir, "secret.txt")},
+		{`readFile("` + filepath.Join(dir, "public", "..", "secret.txt") + `")`, readable, "Permission Denied: fs-read is not granted for " + filepath.Join(dir, "public", "..", "secret.txt")},
+		{`readFile("` + filepath.Join(dir, "public", "link.txt") + `")`, readable, "Permission Denied: fs-read is not granted for " + filepath.Join(dir, "public", "link.txt")},
+		{`readFile("` + filepath.Join(dir, "publicity", "plan.txt") + `")`, readable, "Permission Denied: fs-read is not granted for " + filepath.Join(dir, "publicity", "plan.txt")},
+		{`readFile("` + filepath.Join(dir, "missing.txt") + `")`, readable, "Permission Denied: fs-read is not granted for " + filepath.Join(dir, "missing.txt")},
+		{`writeFile("out.txt", "x")`, NewCapabilities(FS_READ), "Permission Denied: `writeFile` requires the fs-write capability"},
+		{`getenv("HOME")`, NewCapabilities(), "Permission Denied: `getenv` requires the env capability"},
+		{`time()`, revoked, "Permission Denied: `time` requires the time capability"},
+		{`random(10)`, NewCapabilities(TIME), "Permission Denied: `random` requires the random capability"},
+		{`puts("hello")`, NewCapabilities(), "Permission Denied: `puts` requires the stdout capability"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluateWithCapabilities(tt.input, tt.capabilities)
+
+		errorObject, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("Object is not of type Error! Instead received '%T' (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errorObject.Kind != object.PERMISSION_DENIED_ERROR || errorObject.Message != tt.expected {
+			t.Errorf("Object has the incorrect error! Expected '%s' but receieved %+v", tt.expected, errorObject)
+		}
+	}
+}
+
+func TestSeededSource(t *testing.T) {
+	input := `let a = random(1000000); let b = random(1000000); let t = time(); (a, b, t, time() - t)`
+
+	run := func(source Source) string {
+		eval := New()
+		eval.Capabilities = NewCapabilities(TIME, RANDOM)
+		eval.Source = source
+		return eval.Evaluate(parser.New(lexer.New(input)).ParseProgram(), object.NewEnvironment()).Inspect()
+	}
+
+	first := run(NewSeededSource(42))
+	second := run(NewSeededSource(42))
+	other := run(NewSeededSource(7))
+
+	if first != second {
+		t.Errorf("Runs with the same seed differ! Received %s and %s", first, second)
+	}
+
+	if first == other {
+		t.Errorf("Runs with different seeds should differ! Both received %s", first)
+	}
+
+	source := NewSeededSource(42)
+	before := source.Int63n(1000)
+	source.Now()
+	source.Reset()
+
+	if after := source.Int63n(1000); after != before || source.Now() != seededEpoch.Add(time.Millisecond) {
+		t.Errorf("Reset should return the source to its initial state")
+	}
+}
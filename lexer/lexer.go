@@ -8,11 +8,30 @@ import (
 // pos - the current position in the input, which points to the current character
 // readpos - the current reading position in the input, which points to after the current character
 // ch - the current character under examination
+// canTerminate - whether a newline following the previous token should terminate the statement
+// nesting - a stack of the currently open '(' and '{' characters
 type Lexer struct {
 	input   string
 	pos     int
 	readPos int
 	ch      byte
+
+	canTerminate bool
+	nesting      []byte
+}
+
+// The token types which are able to end a statement. A newline directly after one of these is read as a semicolon.
+var statementEnders = map[token.TokenType]bool{
+	token.IDENTIFIERS: true,
+	token.INT:         true,
+	token.STRING:      true,
+	token.TRUE:        true,
+	token.FALSE:       true,
+	token.NULL:        true,
+	token.RETURN:      true,
+	token.YIELD:       true,
+	token.RPAREN:      true,
+	token.RBRACE:      true,
 }
 
 // This function takes in an input string and returns a Lexer struct
@@ -37,12 +56,42 @@ func (lexer *Lexer) readChar() {
 	lexer.readPos += 1
 }
 
-// This function returns a token based on the current character that we are looking at within the input
+// This function returns the next token in the input, inserting automatic semicolons at the end of lines.
+// A newline is read as a semicolon (with the literal "\n") when all of the following hold:
+//   - the last token on the line is an identifier, a literal, 'return', ')' or '}'
+//   - the newline is not inside parentheses, unless a '{' has been opened within them since
+//   - the next token is not ')', '}' or the end of the input
+//
+// This means that a line ending in a binary operator, a comma or an open parenthesis continues onto the next line.
 func (lexer *Lexer) NextToken() token.Token {
-	var tok token.Token
-
 	// Skip any whitespace
-	lexer.skipWhiteSpace()
+	newline := lexer.skipWhiteSpace()
+
+	if newline && lexer.canTerminate && lexer.ch != ')' && lexer.ch != '}' && lexer.ch != 0 {
+		lexer.canTerminate = false
+		return token.Token{Type: token.SEMICOLON, Literal: "\n"}
+	}
+
+	tok := lexer.readToken()
+
+	switch tok.Type {
+	case token.LPAREN, token.LBRACE:
+		lexer.nesting = append(lexer.nesting, tok.Literal[0])
+	case token.RPAREN, token.RBRACE:
+		if len(lexer.nesting) > 0 {
+			lexer.nesting = lexer.nesting[:len(lexer.nesting)-1]
+		}
+	}
+
+	insideParens := len(lexer.nesting) > 0 && lexer.nesting[len(lexer.nesting)-1] == '('
+	lexer.canTerminate = statementEnders[tok.Type] && !insideParens
+
+	return tok
+}
+
+// This function returns a token based on the current character that we are looking at within the input
+func (lexer *Lexer) readToken() token.Token {
+	var tok token.Token
 
 	// Based on the current character return the appropriate token
 	switch lexer.ch {
@@ -52,15 +101,40 @@ func (lexer *Lexer) NextToken() token.Token {
 			lexer.readChar()
 			newLiteral := string(firstChar) + string(lexer.ch)
 			tok = token.Token{Type: token.EQ, Literal: newLiteral}
+		} else if lexer.peekChar() == '>' {
+			firstChar := lexer.ch
+			lexer.readChar()
+			newLiteral := string(firstChar) + string(lexer.ch)
+			tok = token.Token{Type: token.ARROW, Literal: newLiteral}
 		} else {
 			tok = newToken(token.ASSIGN, lexer.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, lexer.ch)
+		tok = lexer.readCompoundAssignment(token.PLUS, token.PLUS_EQ)
 	case ',':
 		tok = newToken(token.COMMA, lexer.ch)
 	case ';':
 		tok = newToken(token.SEMICOLON, lexer.ch)
+	case ':':
+		tok = newToken(token.COLON, lexer.ch)
+	case '.':
+		if lexer.peekChar() == '.' {
+			firstChar := lexer.ch
+			lexer.readChar()
+			newLiteral := string(firstChar) + string(lexer.ch)
+			tok = token.Token{Type: token.SPREAD, Literal: newLiteral}
+		} else {
+			tok = newToken(token.DOT, lexer.ch)
+		}
+	case '?':
+		if lexer.peekChar() == '?' {
+			firstChar := lexer.ch
+			lexer.readChar()
+			newLiteral := string(firstChar) + string(lexer.ch)
+			tok = token.Token{Type: token.COALESCE, Literal: newLiteral}
+		} else {
+			tok = newToken(token.QUESTION, lexer.ch)
+		}
 	case '(':
 		tok = newToken(token.LPAREN, lexer.ch)
 	case ')':
@@ -70,11 +144,11 @@ func (lexer *Lexer) NextToken() token.Token {
 	case '}':
 		tok = newToken(token.RBRACE, lexer.ch)
 	case '-':
-		tok = newToken(token.MINUS, lexer.ch)
+		tok = lexer.readCompoundAssignment(token.MINUS, token.MINUS_EQ)
 	case '*':
-		tok = newToken(token.ASTERISK, lexer.ch)
+		tok = lexer.readCompoundAssignment(token.ASTERISK, token.STAR_EQ)
 	case '/':
-		tok = newToken(token.SLASH, lexer.ch)
+		tok = lexer.readCompoundAssignment(token.SLASH, token.SLASH_EQ)
 	case '!':
 		if lexer.peekChar() == '=' {
 			firstChar := lexer.ch
@@ -87,7 +161,23 @@ func (lexer *Lexer) NextToken() token.Token {
 	case '<':
 		tok = newToken(token.LT, lexer.ch)
 	case '>':
-		tok = newToken(token.GT, lexer.ch)
+		if lexer.peekChar() == '>' {
+			firstChar := lexer.ch
+			lexer.readChar()
+			newLiteral := string(firstChar) + string(lexer.ch)
+			tok = token.Token{Type: token.COMPOSE, Literal: newLiteral}
+		} else {
+			tok = newToken(token.GT, lexer.ch)
+		}
+	case '|':
+		if lexer.peekChar() == '>' {
+			firstChar := lexer.ch
+			lexer.readChar()
+			newLiteral := string(firstChar) + string(lexer.ch)
+			tok = token.Token{Type: token.PIPELINE, Literal: newLiteral}
+		} else {
+			tok = newToken(token.PIPE, lexer.ch)
+		}
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
@@ -113,6 +203,16 @@ func (lexer *Lexer) NextToken() token.Token {
 	return tok
 }
 
+// This helper function returns the compound assignment token if the current operator is followed by '=', such as '+=', otherwise the operator itself
+func (lexer *Lexer) readCompoundAssignment(operator token.TokenType, compound token.TokenType) token.Token {
+	if lexer.peekChar() == '=' {
+		firstChar := lexer.ch
+		lexer.readChar()
+		return token.Token{Type: compound, Literal: string(firstChar) + string(lexer.ch)}
+	}
+	return newToken(operator, lexer.ch)
+}
+
 // This helper function returns a new Token
 func newToken(tokenType token.TokenType, ch byte) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
@@ -148,11 +248,16 @@ func (lexer *Lexer) readNumber() string {
 	return lexer.input[currPos:lexer.pos]
 }
 
-// This function skips any existing whitespace
-func (lexer *Lexer) skipWhiteSpace() {
+// This function skips any existing whitespace and reports whether a newline was skipped
+func (lexer *Lexer) skipWhiteSpace() bool {
+	newline := false
 	for lexer.ch == ' ' || lexer.ch == '\t' || lexer.ch == '\n' || lexer.ch == '\r' {
+		if lexer.ch == '\n' {
+			newline = true
+		}
 		lexer.readChar()
 	}
+	return newline
 }
 
 // This helper function will check for two character operators such as == and !=
@@ -388,6 +388,7 @@ func TestNextTokenExtendedTwoCharOperators(t *testing.T) {
 		{token.FALSE, "false"},
 		{token.SEMICOLON, ";"},
 		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
 		{token.INT, "11"},
 		{token.EQ, "=="},
 		{token.INT, "11"},
@@ -397,6 +398,7 @@ func TestNextTokenExtendedTwoCharOperators(t *testing.T) {
 		{token.INT, "15"},
 		{token.SEMICOLON, ";"},
 		{token.STRING, "foobar"},
+		{token.SEMICOLON, "\n"},
 		{token.STRING, "foo bar"},
 		{token.EOF, ""},
 	}
@@ -414,3 +416,391 @@ func TestNextTokenExtendedTwoCharOperators(t *testing.T) {
 		}
 	}
 }
+
+func TestNextTokenAutomaticSemicolons(t *testing.T) {
+	// Create test string
+	input := `let a = 1
+	let b = a +
+		2
+	add(a,
+		b
+	)
+	let f = fn(x) {
+		return
+	}
+	let g = fn() {
+		yield
+		1
+	}
+	(a
+	 + b)
+	`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "a"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, "\n"},
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "b"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIERS, "a"},
+		{token.PLUS, "+"},
+		{token.INT, "2"},
+		{token.SEMICOLON, "\n"},
+		{token.IDENTIFIERS, "add"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "a"},
+		{token.COMMA, ","},
+		{token.IDENTIFIERS, "b"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, "\n"},
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "f"},
+		{token.ASSIGN, "="},
+		{token.FUNCTION, "fn"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.RETURN, "return"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "g"},
+		{token.ASSIGN, "="},
+		{token.FUNCTION, "fn"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.YIELD, "yield"},
+		{token.SEMICOLON, "\n"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "a"},
+		{token.PLUS, "+"},
+		{token.IDENTIFIERS, "b"},
+		{token.RPAREN, ")"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenStruct(t *testing.T) {
+	// Create test string
+	input := `struct Point { x, y }
+	Point { x: p.x, ..p }`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRUCT, "struct"},
+		{token.IDENTIFIERS, "Point"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIERS, "x"},
+		{token.COMMA, ","},
+		{token.IDENTIFIERS, "y"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
+		{token.IDENTIFIERS, "Point"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIERS, "x"},
+		{token.COLON, ":"},
+		{token.IDENTIFIERS, "p"},
+		{token.DOT, "."},
+		{token.IDENTIFIERS, "x"},
+		{token.COMMA, ","},
+		{token.SPREAD, ".."},
+		{token.IDENTIFIERS, "p"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenClass(t *testing.T) {
+	// Create test string
+	input := `class Counter(Base) { inc(self) { self.n += 1 } }
+	a -= 1; b *= 2; c /= 3; d = 4`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.CLASS, "class"},
+		{token.IDENTIFIERS, "Counter"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "Base"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIERS, "inc"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "self"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIERS, "self"},
+		{token.DOT, "."},
+		{token.IDENTIFIERS, "n"},
+		{token.PLUS_EQ, "+="},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
+		{token.IDENTIFIERS, "a"},
+		{token.MINUS_EQ, "-="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIERS, "b"},
+		{token.STAR_EQ, "*="},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIERS, "c"},
+		{token.SLASH_EQ, "/="},
+		{token.INT, "3"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIERS, "d"},
+		{token.ASSIGN, "="},
+		{token.INT, "4"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenConditional(t *testing.T) {
+	// Create test string
+	input := `x ? y : null ?? z`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENTIFIERS, "x"},
+		{token.QUESTION, "?"},
+		{token.IDENTIFIERS, "y"},
+		{token.COLON, ":"},
+		{token.NULL, "null"},
+		{token.COALESCE, "??"},
+		{token.IDENTIFIERS, "z"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenPipeline(t *testing.T) {
+	// Create test string
+	input := `x |> f >> g > h | y`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENTIFIERS, "x"},
+		{token.PIPELINE, "|>"},
+		{token.IDENTIFIERS, "f"},
+		{token.COMPOSE, ">>"},
+		{token.IDENTIFIERS, "g"},
+		{token.GT, ">"},
+		{token.IDENTIFIERS, "h"},
+		{token.PIPE, "|"},
+		{token.IDENTIFIERS, "y"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenMatch(t *testing.T) {
+	// Create test string
+	input := `match (x) { 1 | 2 => "small", _ => "big" }`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.MATCH, "match"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.INT, "1"},
+		{token.PIPE, "|"},
+		{token.INT, "2"},
+		{token.ARROW, "=>"},
+		{token.STRING, "small"},
+		{token.COMMA, ","},
+		{token.IDENTIFIERS, "_"},
+		{token.ARROW, "=>"},
+		{token.STRING, "big"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenModules(t *testing.T) {
+	input := `import "lib/math" as m
+export let f = m.sqrt;`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IMPORT, "import"},
+		{token.STRING, "lib/math"},
+		{token.AS, "as"},
+		{token.IDENTIFIERS, "m"},
+		{token.SEMICOLON, "\n"},
+		{token.EXPORT, "export"},
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "f"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIERS, "m"},
+		{token.DOT, "."},
+		{token.IDENTIFIERS, "sqrt"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
+
+func TestNextTokenConcurrency(t *testing.T) {
+	input := `let task = spawn work(ch)
+select { receive(ch) as v => v, _ => 0 }
+yield`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENTIFIERS, "task"},
+		{token.ASSIGN, "="},
+		{token.SPAWN, "spawn"},
+		{token.IDENTIFIERS, "work"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "ch"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, "\n"},
+		{token.SELECT, "select"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIERS, "receive"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIERS, "ch"},
+		{token.RPAREN, ")"},
+		{token.AS, "as"},
+		{token.IDENTIFIERS, "v"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIERS, "v"},
+		{token.COMMA, ","},
+		{token.IDENTIFIERS, "_"},
+		{token.ARROW, "=>"},
+		{token.INT, "0"},
+		{token.RBRACE, "}"},
+		{token.SEMICOLON, "\n"},
+		{token.YIELD, "yield"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, tt := range tests {
+		token := lexer.NextToken()
+		if token.Type != tt.expectedType {
+			t.Fatalf("Tests[%d] - TokenType Wrong! Expected=%q, Got=%q", i, tt.expectedType, token.Type)
+		}
+
+		if token.Literal != tt.expectedLiteral {
+			t.Fatalf("Tests[%d] - Token Literal Wrong! Expected=%q, Got=%q", i, tt.expectedLiteral, token.Literal)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package monkey
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+// This function converts a Go value into a Monkey value.
+// Integers, strings, booleans and nil map to their Monkey counterparts, slices become tuples
+// and values which are already objects are returned unchanged. Go functions and structs are bound with Bind.
+func ToObject(value interface{}) (object.Object, error) {
+	switch value := value.(type) {
+	case nil:
+		return evaluator.NULL, nil
+	case object.Object:
+		return value, nil
+	case bool:
+		if value {
+			return evaluator.TRUE, nil
+		}
+		return evaluator.FALSE, nil
+	case string:
+		return &object.String{Value: value}, nil
+	case int:
+		return &object.Integer{Value: int64(value)}, nil
+	case int8:
+		return &object.Integer{Value: int64(value)}, nil
+	case int16:
+		return &object.Integer{Value: int64(value)}, nil
+	case int32:
+		return &object.Integer{Value: int64(value)}, nil
+	case int64:
+		return &object.Integer{Value: value}, nil
+	case uint8:
+		return &object.Integer{Value: int64(value)}, nil
+	case uint16:
+		return &object.Integer{Value: int64(value)}, nil
+	case uint32:
+		return &object.Integer{Value: int64(value)}, nil
+	case []interface{}:
+		elements := make([]object.Object, len(value))
+		for index, element := range value {
+			obj, err := ToObject(element)
+			if err != nil {
+				return nil, err
+			}
+			elements[index] = obj
+		}
+		return &object.Tuple{Elements: elements}, nil
+	default:
+		return fromValue(reflect.ValueOf(value))
+	}
+}
+
+// This function converts a Monkey value into a Go value.
+// Integers become int64, strings become string, booleans become bool, null becomes nil and tuples become []interface{}.
+// Any other object, such as a function, is returned unchanged.
+func ToGo(obj object.Object) interface{} {
+	switch obj := obj.(type) {
+	case nil, *object.Null:
+		return nil
+	case *object.Integer:
+		return obj.Value
+	case *object.String:
+		return obj.Value
+	case *object.Boolean:
+		return obj.Value
+	case *object.Tuple:
+		elements := make([]interface{}, len(obj.Elements))
+		for index, element := range obj.Elements {
+			elements[index] = ToGo(element)
+		}
+		return elements
+	default:
+		return obj
+	}
+}
+
+// This function returns the Go value of a Monkey integer
+func AsInt(obj object.Object) (int64, error) {
+	integer, ok := obj.(*object.Integer)
+	if !ok {
+		return 0, typeError(object.INTEGER_OBJ, obj)
+	}
+	return integer.Value, nil
+}
+
+// This function returns the Go value of a Monkey string
+func AsString(obj object.Object) (string, error) {
+	str, ok := obj.(*object.String)
+	if !ok {
+		return "", typeError(object.STRING_OBJ, obj)
+	}
+	return str.Value, nil
+}
+
+// This function returns the Go value of a Monkey boolean
+func AsBool(obj object.Object) (bool, error) {
+	boolean, ok := obj.(*object.Boolean)
+	if !ok {
+		return false, typeError(object.BOOLEAN_OBJ, obj)
+	}
+	return boolean.Value, nil
+}
+
+// This helper function describes a Monkey value which has the wrong type for a conversion
+func typeError(expected object.ObjectType, obj object.Object) error {
+	if obj == nil {
+		return fmt.Errorf("expected %s but received nothing", expected)
+	}
+	return fmt.Errorf("expected %s but received %s", expected, obj.Type())
+}
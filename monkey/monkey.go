@@ -0,0 +1,276 @@
+// Package monkey lets Go programs embed the Monkey interpreter without driving the lexer, parser and evaluator by hand.
+package monkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+	"github.com/armansandhu/monkey_interpreter/snapshot"
+)
+
+// Interpreter evaluates Monkey source against a global environment which persists between calls,
+// so values defined by one call to Eval can be used by the next.
+type Interpreter struct {
+	evaluator *evaluator.Evaluator
+	env       *object.Environment
+}
+
+// ErrFrozen is returned by Set and Bind when the interpreter's globals have been frozen
+var ErrFrozen = errors.New("the globals are frozen")
+
+// Option configures an Interpreter when it is created
+type Option func(*Interpreter)
+
+// This option sets the directories searched for imported modules
+func WithSearchPath(directories ...string) Option {
+	return func(i *Interpreter) {
+		i.evaluator.SearchPath = append(i.evaluator.SearchPath, directories...)
+	}
+}
+
+// This option replaces the builtins available to the interpreter. The registry is used directly, not copied.
+func WithBuiltins(builtins *evaluator.Builtins) Option {
+	return func(i *Interpreter) {
+		i.evaluator.Builtins = builtins
+	}
+}
+
+// This option caps the resources each call to Eval, EvalFile or Call may use
+func WithLimits(limits evaluator.Limits) Option {
+	return func(i *Interpreter) {
+		i.evaluator.Limits = limits
+	}
+}
+
+// This option sets the capabilities granted to the builtins which perform I/O. Without it none are granted.
+func WithCapabilities(capabilities *evaluator.Capabilities) Option {
+	return func(i *Interpreter) {
+		i.evaluator.Capabilities = capabilities
+	}
+}
+
+// This option sets the source of the time and random numbers used by builtins
+func WithSource(source evaluator.Source) Option {
+	return func(i *Interpreter) {
+		i.evaluator.Source = source
+	}
+}
+
+// This option sets the writer which puts, print and printf write to. They also need the stdout capability.
+func WithOutput(w io.Writer) Option {
+	return func(i *Interpreter) {
+		i.evaluator.Output = w
+	}
+}
+
+// This option runs the interpreter in deterministic mode, with the time and random numbers produced from seed
+func WithSeed(seed int64) Option {
+	return WithSource(evaluator.NewSeededSource(seed))
+}
+
+// This option encloses the interpreter's globals in a frozen environment, such as one holding preloaded library
+// functions. Many interpreters, each used by its own goroutine, can share the same parent.
+// The parent is frozen by New if it is not already, so it can no longer be changed through its owner either.
+func WithParent(parent *object.Environment) Option {
+	return func(i *Interpreter) {
+		parent.Freeze()
+		i.env = object.NewEnclosedEnvironment(parent)
+	}
+}
+
+// This function creates an Interpreter with an empty global environment.
+// An Interpreter may only be used by one goroutine at a time.
+func New(opts ...Option) *Interpreter {
+	interpreter := &Interpreter{
+		evaluator: evaluator.New(),
+		env:       object.NewEnvironment(),
+	}
+
+	for _, opt := range opts {
+		opt(interpreter)
+	}
+
+	return interpreter
+}
+
+// ParseError is returned when the source handed to the interpreter is not a valid program
+type ParseError struct {
+	Errors []string
+}
+
+func (pe *ParseError) Error() string {
+	return "Parse Error: " + strings.Join(pe.Errors, "; ")
+}
+
+// RuntimeError is returned when evaluating a program produces a Monkey error
+type RuntimeError struct {
+	Object *object.Error
+}
+
+func (re *RuntimeError) Error() string {
+	return re.Object.Message
+}
+
+// Result describes a completed run. Seed is only meaningful when Deterministic is true, in which case running the same
+// sources in the same order on a new interpreter created WithSeed(Seed) reproduces the runs exactly. The seeded source
+// carries on from one run to the next, and starts again from its seed only when the interpreter is restored.
+type Result struct {
+	Value         object.Object
+	Err           error
+	Usage         evaluator.Usage
+	Seed          int64
+	Deterministic bool
+}
+
+// This method evaluates src like Eval, returning a Result which also records how the run can be reproduced
+func (i *Interpreter) Run(ctx context.Context, src string) *Result {
+	value, err := i.Eval(ctx, src)
+
+	run := &Result{Value: value, Err: err, Usage: i.evaluator.Usage()}
+	if source, ok := i.evaluator.Source.(*evaluator.SeededSource); ok {
+		run.Seed = source.Seed()
+		run.Deterministic = true
+	}
+
+	return run
+}
+
+// This method parses and evaluates src, returning the value of its last statement
+func (i *Interpreter) Eval(ctx context.Context, src string) (object.Object, error) {
+	i.begin()
+
+	prsr := parser.New(lexer.New(src))
+	program := prsr.ParseProgram()
+	if len(prsr.Errors()) != 0 {
+		return nil, &ParseError{Errors: prsr.Errors()}
+	}
+
+	return result(ctx, i.evaluator.EvaluateContext(ctx, program, i.env))
+}
+
+// This method evaluates the file at path. Modules it imports are resolved relative to the file.
+func (i *Interpreter) EvalFile(ctx context.Context, path string) (object.Object, error) {
+	i.begin()
+	return result(ctx, i.evaluator.EvaluateFileContext(ctx, path, i.env))
+}
+
+// This method returns the interpreter's builtin registry, which can be changed to add, shadow or remove builtins
+func (i *Interpreter) Builtins() *evaluator.Builtins {
+	return i.evaluator.Builtins
+}
+
+// This method returns the capabilities granted to the interpreter, which can be changed between runs
+func (i *Interpreter) Capabilities() *evaluator.Capabilities {
+	return i.evaluator.Capabilities
+}
+
+// This method reports the resources used by the most recent call to Eval, EvalFile or Call
+func (i *Interpreter) Usage() evaluator.Usage {
+	return i.evaluator.Usage()
+}
+
+// This method defines a global, converting value with ToObject
+func (i *Interpreter) Set(name string, value interface{}) error {
+	obj, err := ToObject(value)
+	if err != nil {
+		return err
+	}
+
+	return i.define(name, obj)
+}
+
+// This helper method defines a global, returning ErrFrozen if the globals are frozen
+func (i *Interpreter) define(name string, obj object.Object) error {
+	if i.env.Frozen() {
+		return fmt.Errorf("cannot set %s: %w", name, ErrFrozen)
+	}
+
+	i.env.Set(name, obj)
+	return nil
+}
+
+// This method returns the interpreter's global environment, for example to freeze it and share it using WithParent
+func (i *Interpreter) Globals() *object.Environment {
+	return i.env
+}
+
+// This method writes the interpreter's globals, and every value and closure reachable from them, to w
+func (i *Interpreter) Snapshot(w io.Writer) error {
+	return snapshot.Save(w, i.env)
+}
+
+// This method replaces the interpreter's globals with ones read from a snapshot written by Snapshot.
+// Bound builtin methods are looked up in the interpreter's own builtins, and a seeded source starts again from its seed.
+func (i *Interpreter) Restore(r io.Reader) error {
+	env, err := snapshot.LoadWith(r, i.evaluator.Builtins)
+	if err != nil {
+		return err
+	}
+
+	i.env = env
+	if source, ok := i.evaluator.Source.(*evaluator.SeededSource); ok {
+		source.Reset()
+	}
+	return nil
+}
+
+// This method stops the generators and tasks created by the interpreter's programs which have not finished.
+// It should be called once the interpreter is no longer needed.
+func (i *Interpreter) Close() {
+	i.evaluator.Close()
+}
+
+// This method looks up a global
+func (i *Interpreter) Get(name string) (object.Object, bool) {
+	return i.env.Get(name)
+}
+
+// This method calls a Monkey function, such as a callback a script passed to the host, converting args with ToObject
+func (i *Interpreter) Call(fn object.Object, args ...interface{}) (object.Object, error) {
+	return i.CallContext(context.Background(), fn, args...)
+}
+
+// This method calls a Monkey function like Call, stopping it once ctx is cancelled or its deadline passes
+func (i *Interpreter) CallContext(ctx context.Context, fn object.Object, args ...interface{}) (object.Object, error) {
+	i.begin()
+
+	arguments := make([]object.Object, len(args))
+	for index, arg := range args {
+		obj, err := ToObject(arg)
+		if err != nil {
+			return nil, err
+		}
+		arguments[index] = obj
+	}
+
+	return result(ctx, i.evaluator.CallContext(ctx, fn, arguments...))
+}
+
+// This helper method prepares the interpreter for a new run, counting usage afresh
+func (i *Interpreter) begin() {
+	i.evaluator.ResetUsage()
+}
+
+// This helper function turns the value produced by the evaluator into the result returned to the host.
+// An evaluation stopped by ctx reports ctx.Err(), so callers can check for context.Canceled or context.DeadlineExceeded.
+func result(ctx context.Context, obj object.Object) (object.Object, error) {
+	if errorObject, ok := obj.(*object.Error); ok {
+		if errorObject.Kind == object.CANCELLED_ERROR && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &RuntimeError{Object: errorObject}
+	}
+
+	if obj == nil {
+		return evaluator.NULL, nil
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,479 @@
+package monkey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+func TestInterpreterEval(t *testing.T) {
+	interpreter := New()
+	ctx := context.Background()
+
+	if _, err := interpreter.Eval(ctx, "let double = x => x * 2"); err != nil {
+		t.Fatalf("Eval returned an unexpected error: %s", err)
+	}
+
+	result, err := interpreter.Eval(ctx, "double(21)")
+	if err != nil {
+		t.Fatalf("Eval returned an unexpected error: %s", err)
+	}
+
+	value, err := AsInt(result)
+	if err != nil || value != 42 {
+		t.Errorf("Incorrect result! Expected 42 but instead received %s (%v)", result.Inspect(), err)
+	}
+}
+
+func TestInterpreterErrors(t *testing.T) {
+	interpreter := New()
+	ctx := context.Background()
+
+	_, err := interpreter.Eval(ctx, "let = 1")
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		t.Errorf("Expected a *ParseError! Instead received '%T' (%v)", err, err)
+	}
+
+	_, err = interpreter.Eval(ctx, "1 + true")
+	var runtimeError *RuntimeError
+	if !errors.As(err, &runtimeError) {
+		t.Fatalf("Expected a *RuntimeError! Instead received '%T' (%v)", err, err)
+	}
+
+	if runtimeError.Error() != "Type Mismatch: INTEGER + BOOLEAN" {
+		t.Errorf("Incorrect error message! Received '%s'", runtimeError.Error())
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := interpreter.Eval(cancelled, "1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled! Instead received '%v'", err)
+	}
+}
+
+func TestInterpreterGlobalsAndCall(t *testing.T) {
+	interpreter := New()
+	ctx := context.Background()
+
+	if err := interpreter.Set("greeting", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := interpreter.Set("limit", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := interpreter.Set("bad", map[string]int{}); err == nil {
+		t.Errorf("Set should refuse values which cannot be converted")
+	}
+
+	if _, err := interpreter.Eval(ctx, `let callback = fn(name, n) { if (n < limit) { greeting + " " + name } else { false } }`); err != nil {
+		t.Fatal(err)
+	}
+
+	callback, ok := interpreter.Get("callback")
+	if !ok {
+		t.Fatalf("Global 'callback' was not defined")
+	}
+
+	result, err := interpreter.Call(callback, "world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := AsString(result); value != "hello world" {
+		t.Errorf("Incorrect result! Expected 'hello world' but instead received '%s'", result.Inspect())
+	}
+
+	result, err = interpreter.Call(callback, "world", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := AsBool(result); err != nil || value {
+		t.Errorf("Incorrect result! Expected false but instead received '%s'", result.Inspect())
+	}
+
+	if _, err := interpreter.Call(callback, "world"); err == nil {
+		t.Errorf("Call should report an arity mismatch")
+	}
+
+	if _, err := interpreter.Eval(ctx, `let spin = fn(n) { if (n < 2) { n } else { spin(n - 1) + spin(n - 2) } }`); err != nil {
+		t.Fatal(err)
+	}
+	spin, _ := interpreter.Get("spin")
+
+	deadline, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := interpreter.CallContext(deadline, spin, 40); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded! Instead received '%v'", err)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	obj, err := ToObject([]interface{}{1, "two", true, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Inspect() != "(1, two, true, null)" {
+		t.Errorf("Incorrect conversion! Received '%s'", obj.Inspect())
+	}
+
+	values, ok := ToGo(obj).([]interface{})
+	if !ok || len(values) != 4 {
+		t.Fatalf("Incorrect conversion! Received '%#v'", ToGo(obj))
+	}
+
+	if values[0] != int64(1) || values[1] != "two" || values[2] != true || values[3] != nil {
+		t.Errorf("Incorrect conversion! Received '%#v'", values)
+	}
+
+	if _, err := AsInt(&object.String{Value: "1"}); err == nil {
+		t.Errorf("AsInt should refuse a string")
+	}
+}
+
+func TestInterpreterEvalFile(t *testing.T) {
+	dir := t.TempDir()
+	library := filepath.Join(dir, "lib")
+	os.MkdirAll(library, 0o755)
+	os.WriteFile(filepath.Join(library, "shapes.monkey"), []byte(`export let area = fn(w, h) { w * h }`), 0o644)
+	os.WriteFile(filepath.Join(dir, "main.monkey"), []byte(`import "shapes"; let result = shapes.area(6, 7)`), 0o644)
+
+	capabilities := evaluator.NewCapabilities()
+	capabilities.AllowRead(library)
+
+	interpreter := New(WithSearchPath(library), WithCapabilities(capabilities))
+	if _, err := interpreter.EvalFile(context.Background(), filepath.Join(dir, "main.monkey")); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _ := interpreter.Get("result")
+	if value, err := AsInt(result); err != nil || value != 42 {
+		t.Errorf("Incorrect result! Expected 42 but instead received '%v'", result)
+	}
+}
+
+func TestInterpreterBuiltins(t *testing.T) {
+	restricted := evaluator.NewBuiltins()
+	restricted.Register("answer", func(args ...object.Object) object.Object {
+		return &object.Integer{Value: 42}
+	})
+
+	tenant := New(WithBuiltins(restricted))
+	other := New()
+
+	if _, err := tenant.Eval(context.Background(), `len("abc")`); err == nil {
+		t.Errorf("Builtins missing from the registry should not be callable")
+	}
+
+	if result, err := tenant.Eval(context.Background(), `answer()`); err != nil || result.Inspect() != "42" {
+		t.Errorf("Incorrect result! Expected 42 but instead received '%v' (%v)", result, err)
+	}
+
+	if _, err := other.Eval(context.Background(), `answer()`); err == nil {
+		t.Errorf("Builtins registered for one interpreter should not be visible to another")
+	}
+
+	other.Builtins().Remove("len")
+	if _, err := New().Eval(context.Background(), `len("abc")`); err != nil {
+		t.Errorf("Removing a builtin from one interpreter should not affect others: %s", err)
+	}
+}
+
+func TestInterpreterSharedBuiltins(t *testing.T) {
+	trusted := New(WithCapabilities(evaluator.NewCapabilities(evaluator.ENV)))
+	sandboxed := New(WithBuiltins(trusted.Builtins()))
+
+	if _, err := trusted.Eval(context.Background(), `getenv("HOME")`); err != nil {
+		t.Errorf("getenv should be callable with the env capability: %s", err)
+	}
+
+	_, err := sandboxed.Eval(context.Background(), `getenv("HOME")`)
+	if err == nil || err.Error() != "Permission Denied: `getenv` requires the env capability" {
+		t.Errorf("Builtins shared from another interpreter should use the capabilities of the caller! Instead received '%v'", err)
+	}
+}
+
+func TestInterpreterDeadline(t *testing.T) {
+	interpreter := New()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := interpreter.Eval(ctx, `let spin = fn(n) { if (n < 2) { n } else { spin(n - 1) + spin(n - 2) } }; spin(50)`)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded! Instead received '%v'", err)
+	}
+
+	if result, err := interpreter.Eval(context.Background(), `spin(10)`); err != nil || result.Inspect() != "55" {
+		t.Errorf("Interpreter should be usable after a cancelled run! Received '%v' (%v)", result, err)
+	}
+
+	runaway, cancelRunaway := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelRunaway()
+
+	_, err = interpreter.Eval(runaway, `let f = fn(n) { f(n + 1) }; f(0)`)
+	var runtimeError *RuntimeError
+	if !errors.As(err, &runtimeError) || runtimeError.Object.Kind != object.RESOURCE_EXHAUSTED_ERROR {
+		t.Fatalf("Unbounded recursion should stop at the call depth limit! Instead received '%v'", err)
+	}
+
+	if runtimeError.Error() != fmt.Sprintf("Resource Exhausted: call depth limit of %d exceeded", evaluator.DefaultMaxDepth) {
+		t.Errorf("Incorrect error message! Received '%s'", runtimeError.Error())
+	}
+
+	if result, err := interpreter.Eval(context.Background(), `spin(10)`); err != nil || result.Inspect() != "55" {
+		t.Errorf("Interpreter should be usable after reaching the call depth limit! Received '%v' (%v)", result, err)
+	}
+}
+
+func TestInterpreterLimits(t *testing.T) {
+	interpreter := New(WithLimits(evaluator.Limits{MaxSteps: 200}))
+
+	_, err := interpreter.Eval(context.Background(), `let f = fn(n) { if (n == 0) { 0 } else { f(n - 1) } }; f(100)`)
+
+	var runtimeError *RuntimeError
+	if !errors.As(err, &runtimeError) || runtimeError.Object.Kind != object.RESOURCE_EXHAUSTED_ERROR {
+		t.Fatalf("Expected a resource exhausted error! Instead received '%v'", err)
+	}
+
+	if usage := interpreter.Usage(); usage.Steps != 201 {
+		t.Errorf("Usage should report the steps taken! Instead received %+v", usage)
+	}
+
+	if _, err := interpreter.Eval(context.Background(), `f(3)`); err != nil {
+		t.Errorf("Limits should apply to each run separately: %s", err)
+	}
+}
+
+func TestInterpreterDeterministicRuns(t *testing.T) {
+	src := `(random(1000000), random(1000000), time())`
+	capabilities := evaluator.NewCapabilities(evaluator.TIME, evaluator.RANDOM)
+
+	production := New(WithSeed(time.Now().UnixNano()), WithCapabilities(capabilities))
+	recorded := production.Run(context.Background(), src)
+	if recorded.Err != nil {
+		t.Fatal(recorded.Err)
+	}
+
+	if !recorded.Deterministic {
+		t.Fatalf("Run in deterministic mode was not recorded as deterministic")
+	}
+
+	again := production.Run(context.Background(), src)
+	if again.Value.Inspect() == recorded.Value.Inspect() {
+		t.Errorf("The seeded source should carry on between runs! Received %s twice", again.Value.Inspect())
+	}
+
+	replayer := New(WithSeed(recorded.Seed), WithCapabilities(capabilities))
+	for _, expected := range []*Result{recorded, again} {
+		replay := replayer.Run(context.Background(), src)
+		if replay.Value.Inspect() != expected.Value.Inspect() {
+			t.Errorf("Replay differs from the recorded run! Expected %s but instead received %s", expected.Value.Inspect(), replay.Value.Inspect())
+		}
+	}
+
+	if normal := New().Run(context.Background(), `1`); normal.Deterministic {
+		t.Errorf("Runs are only deterministic when a seed is given")
+	}
+}
+
+func TestConcurrentInterpretersSharingFrozenParent(t *testing.T) {
+	library := New()
+	_, err := library.Eval(context.Background(), `
+	let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }
+	let greet = fn(name) { "hello " + name }
+	class Counter { init(self) { self.n = 0 } inc(self) { self.n += 1 } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared := library.Globals()
+	shared.Freeze()
+
+	var wg sync.WaitGroup
+	failures := make(chan string, 64)
+
+	for worker := 0; worker < 32; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			interpreter := New(WithParent(shared))
+			if err := interpreter.Set("worker", worker); err != nil {
+				failures <- err.Error()
+				return
+			}
+
+			for round := 0; round < 20; round++ {
+				result, err := interpreter.Eval(context.Background(), `
+				let c = Counter()
+				c.inc()
+				let fib = fn(n) { n }
+				(fib(worker), greet("w"), c.n)`)
+				if err != nil {
+					failures <- err.Error()
+					return
+				}
+
+				expected := fmt.Sprintf("(%d, hello w, 1)", worker)
+				if result.Inspect() != expected {
+					failures <- fmt.Sprintf("expected %s but received %s", expected, result.Inspect())
+					return
+				}
+			}
+
+			if _, err := interpreter.Eval(context.Background(), `greet = fn(x) { x }`); err == nil {
+				failures <- "assigning to a frozen binding should fail"
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+	close(failures)
+
+	for failure := range failures {
+		t.Error(failure)
+	}
+
+	if result, err := New(WithParent(shared)).Eval(context.Background(), `fib(10)`); err != nil || result.Inspect() != "55" {
+		t.Errorf("Shared library should be unchanged! Received '%v' (%v)", result, err)
+	}
+}
+
+func TestInterpreterFrozenGlobals(t *testing.T) {
+	library := New()
+	library.Globals().Freeze()
+
+	if err := library.Set("answer", 42); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Set on frozen globals should return ErrFrozen! Instead received '%v'", err)
+	}
+
+	if err := library.Bind("double", func(x int) int { return x * 2 }); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Bind on frozen globals should return ErrFrozen! Instead received '%v'", err)
+	}
+}
+
+func TestInterpreterSnapshot(t *testing.T) {
+	session := New()
+	if _, err := session.Eval(context.Background(), `let count = 0; let bump = fn() { count += 1 }; bump(); bump()`); err != nil {
+		t.Fatal(err)
+	}
+
+	var saved bytes.Buffer
+	if err := session.Snapshot(&saved); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := New()
+	if err := resumed.Restore(&saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := resumed.Eval(context.Background(), `bump(); count`); err != nil || result.Inspect() != "3" {
+		t.Errorf("Restored session returned the incorrect result! Expected 3 but instead received '%v' (%v)", result, err)
+	}
+}
+
+func TestInterpreterRestoreBoundMethods(t *testing.T) {
+	withShout := func() *Interpreter {
+		interpreter := New()
+		interpreter.Builtins().RegisterMethod(object.STRING_OBJ, "shout", func(args ...object.Object) object.Object {
+			return &object.String{Value: args[0].(*object.String).Value + "!"}
+		})
+		return interpreter
+	}
+
+	session := withShout()
+	if _, err := session.Eval(context.Background(), `let shout = "hey".shout`); err != nil {
+		t.Fatal(err)
+	}
+
+	var saved bytes.Buffer
+	if err := session.Snapshot(&saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := New().Restore(bytes.NewReader(saved.Bytes())); err == nil || err.Error() != "snapshot: STRING has no method 'shout'" {
+		t.Errorf("Restoring a method missing from the interpreter's builtins should fail! Instead received '%v'", err)
+	}
+
+	resumed := withShout()
+	if err := resumed.Restore(bytes.NewReader(saved.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := resumed.Eval(context.Background(), `shout()`); err != nil || result.Inspect() != "hey!" {
+		t.Errorf("Restored method returned the incorrect result! Expected hey! but instead received '%v' (%v)", result, err)
+	}
+}
+
+func TestInterpreterSpawnedHostCalls(t *testing.T) {
+	interpreter := New()
+	interpreter.Bind("slow", func(n int) int {
+		time.Sleep(50 * time.Millisecond)
+		return n * 10
+	})
+
+	start := time.Now()
+	result, err := interpreter.Eval(context.Background(), `let tasks = (spawn slow(1), spawn slow(2), spawn slow(3))
+let (a, b, c) = tasks
+await(a) + await(b) + await(c)`)
+	if err != nil {
+		t.Fatalf("Eval returned an unexpected error: %s", err)
+	}
+
+	if value, _ := AsInt(result); value != 60 {
+		t.Errorf("Incorrect result! Expected 60 but instead received %s", result.Inspect())
+	}
+
+	if elapsed := time.Since(start); elapsed > 140*time.Millisecond {
+		t.Errorf("Spawned host calls did not run concurrently! The run took %s", elapsed)
+	}
+}
+
+func TestInterpreterGenerators(t *testing.T) {
+	interpreter := New()
+	defer interpreter.Close()
+	ctx := context.Background()
+
+	if _, err := interpreter.Eval(ctx, "let evens = fn(n) { yield n; yield n + 2; yield n + 4 }; let g = evens(10)"); err != nil {
+		t.Fatalf("Eval returned an unexpected error: %s", err)
+	}
+
+	for _, expected := range []int64{10, 12, 14} {
+		result, err := interpreter.Eval(ctx, "g.next()")
+		if err != nil {
+			t.Fatalf("Eval returned an unexpected error: %s", err)
+		}
+
+		if value, _ := AsInt(result); value != expected {
+			t.Errorf("Incorrect value from the generator! Expected %d but instead received %s", expected, result.Inspect())
+		}
+	}
+
+	if result, _ := interpreter.Eval(ctx, "g.next()"); result != evaluator.NULL {
+		t.Errorf("Finished generator should produce null! Instead received %s", result.Inspect())
+	}
+}
+
+func TestInterpreterOutput(t *testing.T) {
+	var out bytes.Buffer
+	interpreter := New(WithOutput(&out), WithCapabilities(evaluator.NewCapabilities(evaluator.STDOUT)))
+
+	if _, err := interpreter.Eval(context.Background(), `puts("hello"); printf("%d-%s", 7, "up")`); err != nil {
+		t.Fatalf("Eval returned an unexpected error: %s", err)
+	}
+
+	if out.String() != "hello\n7-up" {
+		t.Errorf("Incorrect output captured! Expected %q but instead received %q", "hello\n7-up", out.String())
+	}
+}
@@ -0,0 +1,298 @@
+package monkey
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+var (
+	objectType = reflect.TypeOf((*object.Object)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// This function exposes a Go function or struct to Monkey.
+//
+// A function becomes a builtin whose arguments are converted to the Go parameter types when it is called.
+// If its last result is an error, a non-nil error is returned to the script as an error object. A single remaining
+// result is returned as is, and several are returned as a tuple.
+//
+// A struct, or a pointer to one, becomes a Monkey struct holding a copy of its exported fields and its methods.
+// A field tagged `monkey:"name"` is exposed under that name.
+func Bind(value interface{}) (object.Object, error) {
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.Kind() == reflect.Func:
+		return bindFunction(v), nil
+	case v.Kind() == reflect.Struct, v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct:
+		return bindStruct(v)
+	default:
+		return nil, fmt.Errorf("cannot bind %T, only functions and structs can be bound", value)
+	}
+}
+
+// This method binds a Go function or struct to a global name
+func (i *Interpreter) Bind(name string, value interface{}) error {
+	obj, err := Bind(value)
+	if err != nil {
+		return err
+	}
+
+	return i.define(name, obj)
+}
+
+// This helper function wraps a Go function in a builtin which converts its arguments and results
+func bindFunction(fn reflect.Value) *object.BuiltIn {
+	fnType := fn.Type()
+
+	return &object.BuiltIn{Function: func(args ...object.Object) (result object.Object) {
+		// Converting the arguments can panic as well as the call, for example on a nil argument
+		defer func() {
+			if r := recover(); r != nil {
+				result = &object.Error{Message: fmt.Sprintf("Go Panic: %v", r)}
+			}
+		}()
+
+		parameters := fnType.NumIn()
+		if fnType.IsVariadic() {
+			if len(args) < parameters-1 {
+				return &object.Error{Message: fmt.Sprintf("Incorrect number of arguments detected! Needed at least %d but instead received %d!", parameters-1, len(args))}
+			}
+		} else if len(args) != parameters {
+			return &object.Error{Message: fmt.Sprintf("Incorrect number of arguments detected! Needed %d but instead received %d!", parameters, len(args))}
+		}
+
+		in := make([]reflect.Value, len(args))
+		for index, arg := range args {
+			var parameterType reflect.Type
+			if fnType.IsVariadic() && index >= parameters-1 {
+				parameterType = fnType.In(parameters - 1).Elem()
+			} else {
+				parameterType = fnType.In(index)
+			}
+
+			value, err := toValue(arg, parameterType)
+			if err != nil {
+				return &object.Error{Message: fmt.Sprintf("Type Mismatch: argument %d %s", index+1, err)}
+			}
+			in[index] = value
+		}
+
+		return fromResults(fn.Call(in))
+	}}
+}
+
+// This helper function converts the results of a Go function call into a single Monkey value
+func fromResults(out []reflect.Value) object.Object {
+	if len(out) > 0 && out[len(out)-1].Type() == errorType {
+		if err := out[len(out)-1]; !err.IsNil() {
+			return &object.Error{Message: err.Interface().(error).Error()}
+		}
+		out = out[:len(out)-1]
+	}
+
+	elements := make([]object.Object, len(out))
+	for index, value := range out {
+		obj, err := fromValue(value)
+		if err != nil {
+			return &object.Error{Message: err.Error()}
+		}
+		elements[index] = obj
+	}
+
+	switch len(elements) {
+	case 0:
+		return evaluator.NULL
+	case 1:
+		return elements[0]
+	default:
+		return &object.Tuple{Elements: elements}
+	}
+}
+
+// This helper function copies the exported fields and the methods of a Go struct into a Monkey struct
+func bindStruct(v reflect.Value) (object.Object, error) {
+	structValue := reflect.Indirect(v)
+	structType := structValue.Type()
+
+	definition := &object.StructDefinition{Name: structType.Name()}
+	fields := make(map[string]object.Object)
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("monkey"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+
+		obj, err := fromValue(structValue.Field(index))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		definition.Fields = append(definition.Fields, name)
+		fields[name] = obj
+	}
+
+	for index := 0; index < v.NumMethod(); index++ {
+		name := v.Type().Method(index).Name
+		definition.Fields = append(definition.Fields, name)
+		fields[name] = bindFunction(v.Method(index))
+	}
+
+	return &object.Struct{Definition: definition, Fields: fields}, nil
+}
+
+// This helper function converts a Go value of any supported type into a Monkey value
+func fromValue(v reflect.Value) (object.Object, error) {
+	if !v.IsValid() {
+		return evaluator.NULL, nil
+	}
+
+	if v.Type().Implements(objectType) {
+		if v.IsNil() {
+			return evaluator.NULL, nil
+		}
+		return v.Interface().(object.Object), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return ToObject(v.Bool())
+	case reflect.String:
+		return &object.String{Value: v.String()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &object.Integer{Value: v.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%d overflows INTEGER", v.Uint())
+		}
+		return &object.Integer{Value: int64(v.Uint())}, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return evaluator.NULL, nil
+		}
+		elements := make([]object.Object, v.Len())
+		for index := range elements {
+			obj, err := fromValue(v.Index(index))
+			if err != nil {
+				return nil, err
+			}
+			elements[index] = obj
+		}
+		return &object.Tuple{Elements: elements}, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return evaluator.NULL, nil
+		}
+		return fromValue(v.Elem())
+	case reflect.Ptr:
+		if v.IsNil() {
+			return evaluator.NULL, nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			return bindStruct(v)
+		}
+		return fromValue(v.Elem())
+	case reflect.Func:
+		if v.IsNil() {
+			return evaluator.NULL, nil
+		}
+		return bindFunction(v), nil
+	case reflect.Struct:
+		return bindStruct(v)
+	default:
+		return nil, fmt.Errorf("cannot convert %s to a Monkey value", v.Type())
+	}
+}
+
+// This helper function converts a Monkey value into a Go value of type t
+func toValue(obj object.Object, t reflect.Type) (reflect.Value, error) {
+	if objectType.AssignableTo(t) || reflect.TypeOf(obj).AssignableTo(t) {
+		if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+			if value := ToGo(obj); value != nil {
+				return reflect.ValueOf(value), nil
+			}
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(obj), nil
+	}
+
+	switch obj := obj.(type) {
+	case *object.Null:
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func:
+			return reflect.Zero(t), nil
+		}
+	case *object.Integer:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value := reflect.New(t).Elem()
+			if value.OverflowInt(obj.Value) {
+				return reflect.Value{}, fmt.Errorf("overflows %s, received %d", t, obj.Value)
+			}
+			value.SetInt(obj.Value)
+			return value, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			value := reflect.New(t).Elem()
+			if obj.Value < 0 || value.OverflowUint(uint64(obj.Value)) {
+				return reflect.Value{}, fmt.Errorf("overflows %s, received %d", t, obj.Value)
+			}
+			value.SetUint(uint64(obj.Value))
+			return value, nil
+		case reflect.Float32, reflect.Float64:
+			return reflect.ValueOf(float64(obj.Value)).Convert(t), nil
+		}
+	case *object.String:
+		if t.Kind() == reflect.String {
+			return reflect.ValueOf(obj.Value).Convert(t), nil
+		}
+	case *object.Boolean:
+		if t.Kind() == reflect.Bool {
+			return reflect.ValueOf(obj.Value).Convert(t), nil
+		}
+	case *object.Tuple:
+		if t.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(t, len(obj.Elements), len(obj.Elements))
+			for index, element := range obj.Elements {
+				value, err := toValue(element, t.Elem())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				slice.Index(index).Set(value)
+			}
+			return slice, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("must be %s but instead received %s", monkeyTypeName(t), obj.Type())
+}
+
+// This helper function names the Monkey type which converts to a Go type, for use in error messages
+func monkeyTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return object.INTEGER_OBJ
+	case reflect.String:
+		return object.STRING_OBJ
+	case reflect.Bool:
+		return object.BOOLEAN_OBJ
+	case reflect.Slice:
+		return object.TUPLE_OBJ
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,169 @@
+package monkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/object"
+)
+
+type testRectangle struct {
+	Width  int
+	Height int
+	Label  string `monkey:"label"`
+	secret int
+}
+
+func (r *testRectangle) Area() int { return r.Width * r.Height }
+
+func (r *testRectangle) Scale(factor int) (*testRectangle, error) {
+	if factor <= 0 {
+		return nil, fmt.Errorf("scale factor must be positive, received %d", factor)
+	}
+	return &testRectangle{Width: r.Width * factor, Height: r.Height * factor, Label: r.Label}, nil
+}
+
+func TestBindFunctions(t *testing.T) {
+	interpreter := New()
+
+	bindings := map[string]interface{}{
+		"repeat": func(s string, n int) (string, error) {
+			if n < 0 {
+				return "", errors.New("negative count")
+			}
+			return strings.Repeat(s, n), nil
+		},
+		"sum": func(values ...int64) int64 {
+			var total int64
+			for _, value := range values {
+				total += value
+			}
+			return total
+		},
+		"divmod":  func(a, b int) (int, int) { return a / b, a % b },
+		"small":   func(n int8) int8 { return n },
+		"words":   func(s string) []string { return strings.Fields(s) },
+		"count":   func(values []int) int { return len(values) },
+		"any":     func(value interface{}) string { return fmt.Sprintf("%T", value) },
+		"typeOf":  func(value object.Object) string { return string(value.Type()) },
+		"nothing": func() {},
+		"explode": func() int { panic("boom") },
+	}
+
+	for name, fn := range bindings {
+		if err := interpreter.Bind(name, fn); err != nil {
+			t.Fatalf("Bind(%s) returned an unexpected error: %s", name, err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("ab", 3)`, "ababab"},
+		{`sum()`, "0"},
+		{`sum(1, 2, 3)`, "6"},
+		{`divmod(7, 2)`, "(3, 1)"},
+		{`words(" a b  c ")`, "(a, b, c)"},
+		{`count((1, 2, 3))`, "3"},
+		{`any(1)`, "int64"},
+		{`any("s")`, "string"},
+		{`typeOf(true)`, "BOOLEAN"},
+		{`nothing()`, "null"},
+	}
+
+	for _, tt := range tests {
+		result, err := interpreter.Eval(context.Background(), tt.input)
+		if err != nil {
+			t.Errorf("Eval(%q) returned an unexpected error: %s", tt.input, err)
+			continue
+		}
+
+		if result.Inspect() != tt.expected {
+			t.Errorf("Incorrect result for %q! Expected '%s' but instead received '%s'", tt.input, tt.expected, result.Inspect())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("ab", -1)`, "negative count"},
+		{`repeat("ab")`, "Incorrect number of arguments detected! Needed 2 but instead received 1!"},
+		{`repeat("ab", "c")`, "Type Mismatch: argument 2 must be INTEGER but instead received STRING"},
+		{`small(300)`, "Type Mismatch: argument 1 overflows int8, received 300"},
+		{`sum(1, true)`, "Type Mismatch: argument 2 must be INTEGER but instead received BOOLEAN"},
+		{`explode()`, "Go Panic: boom"},
+	}
+
+	for _, tt := range errorTests {
+		_, err := interpreter.Eval(context.Background(), tt.input)
+		if err == nil {
+			t.Errorf("Eval(%q) did not return an error", tt.input)
+			continue
+		}
+
+		if err.Error() != tt.expected {
+			t.Errorf("Incorrect error for %q! Expected '%s' but instead received '%s'", tt.input, tt.expected, err.Error())
+		}
+	}
+}
+
+func TestBindFunctionNilArgument(t *testing.T) {
+	bound, err := Bind(func(n int) int { return n })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := bound.(*object.BuiltIn).Function(nil)
+	errorObject, ok := result.(*object.Error)
+	if !ok || !strings.HasPrefix(errorObject.Message, "Go Panic: ") {
+		t.Errorf("A nil argument should be reported as a Go panic! Instead received %+v", result)
+	}
+}
+
+func TestBindStructs(t *testing.T) {
+	interpreter := New()
+
+	if err := interpreter.Bind("rect", &testRectangle{Width: 2, Height: 3, Label: "r", secret: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`rect.Width`, "2"},
+		{`rect.label`, "r"},
+		{`rect.Area()`, "6"},
+		{`rect.Scale(2).Area()`, "24"},
+		{`rect.Scale(2).label`, "r"},
+	}
+
+	for _, tt := range tests {
+		result, err := interpreter.Eval(context.Background(), tt.input)
+		if err != nil {
+			t.Errorf("Eval(%q) returned an unexpected error: %s", tt.input, err)
+			continue
+		}
+
+		if result.Inspect() != tt.expected {
+			t.Errorf("Incorrect result for %q! Expected '%s' but instead received '%s'", tt.input, tt.expected, result.Inspect())
+		}
+	}
+
+	if _, err := interpreter.Eval(context.Background(), `rect.secret`); err == nil || err.Error() != "Unknown Field: testRectangle has no field 'secret'" {
+		t.Errorf("Unexported fields should not be bound! Received '%v'", err)
+	}
+
+	if _, err := interpreter.Eval(context.Background(), `rect.Scale(0)`); err == nil || err.Error() != "scale factor must be positive, received 0" {
+		t.Errorf("Incorrect error returned by a bound method! Received '%v'", err)
+	}
+
+	if _, err := Bind(42); err == nil {
+		t.Errorf("Bind should refuse values which are not functions or structs")
+	}
+}
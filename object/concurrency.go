@@ -0,0 +1,70 @@
+package object
+
+import "sync"
+
+// the struct needed for holding a task started with spawn
+// the task's result is set once when it completes, after which Done is closed
+type Task struct {
+	done   chan struct{}
+	result Object
+}
+
+func NewTask() *Task {
+	return &Task{done: make(chan struct{})}
+}
+
+// Complete records the result of the task and wakes everything waiting for it
+func (t *Task) Complete(result Object) {
+	t.result = result
+	close(t.done)
+}
+
+// Done returns a channel which is closed once the task completes
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Result returns the result of the task. It must only be called after Done is closed.
+func (t *Task) Result() Object {
+	return t.result
+}
+
+func (t *Task) Inspect() string {
+	select {
+	case <-t.done:
+		return "task(done)"
+	default:
+		return "task(running)"
+	}
+}
+func (t *Task) Type() ObjectType { return TASK_OBJ }
+
+// the struct needed for holding a channel which tasks use to pass values to one another
+type Channel struct {
+	C        chan Object
+	Capacity int
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func NewChannel(capacity int) *Channel {
+	return &Channel{C: make(chan Object, capacity), Capacity: capacity}
+}
+
+// Close closes the channel, returning false if it was already closed
+func (c *Channel) Close() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+
+	c.closed = true
+	close(c.C)
+	return true
+}
+
+func (c *Channel) Inspect() string  { return "channel" }
+func (c *Channel) Type() ObjectType { return CHANNEL_OBJ }
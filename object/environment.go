@@ -1,23 +1,124 @@
 package object
 
+import (
+	"sort"
+	"sync"
+)
+
+// Environment holds the bindings of a scope. It is safe for concurrent use, which lets tasks spawned by a program
+// share the environments their functions close over. A frozen Environment can no longer change, so reading it
+// needs no locking and any number of goroutines can enclose it in environments of their own.
 type Environment struct {
-	store map[string]Object
-	outer *Environment
+	mu     sync.RWMutex
+	store  map[string]Object
+	outer  *Environment
+	frozen bool
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
-	obj, ok := e.store[name]
+	obj, ok := e.lookup(name)
 	if !ok && e.outer != nil {
 		obj, ok = e.outer.Get(name)
 	}
 	return obj, ok
 }
 
+// This helper function looks up a name bound directly in this environment
+func (e *Environment) lookup(name string) (Object, bool) {
+	if e.frozen {
+		obj, ok := e.store[name]
+		return obj, ok
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	obj, ok := e.store[name]
+	return obj, ok
+}
+
+// This function binds name in this environment. It panics if the environment is frozen.
 func (e *Environment) Set(name string, value Object) Object {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.frozen {
+		panic("object: Set called on a frozen Environment")
+	}
 	e.store[name] = value
 	return value
 }
 
+// This function updates an existing binding in the closest environment which defines it.
+// It returns false if the name is not defined in this environment or any outer environment, or if it is defined in a frozen one.
+func (e *Environment) Assign(name string, value Object) bool {
+	for env := e; env != nil; env = env.outer {
+		if env.frozen {
+			if _, ok := env.store[name]; ok {
+				return false
+			}
+			continue
+		}
+
+		env.mu.Lock()
+		_, ok := env.store[name]
+		if ok {
+			env.store[name] = value
+		}
+		env.mu.Unlock()
+
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// This function makes the environment and every environment enclosing it read-only.
+// It must be called before the environment is shared between goroutines. Freezing protects the bindings only:
+// values such as class instances reachable from a frozen environment can still be changed through their fields.
+func (e *Environment) Freeze() {
+	// Enclosing environments of a frozen environment are always frozen, and skipping them keeps Freeze free of
+	// writes when it is called again on an environment which is already shared
+	for env := e; env != nil && !env.frozen; env = env.outer {
+		env.mu.Lock()
+		env.frozen = true
+		env.mu.Unlock()
+	}
+}
+
+// This function reports whether the environment is frozen
+func (e *Environment) Frozen() bool {
+	return e.frozen
+}
+
+// This function reports whether the closest binding of name is in a frozen environment, where it cannot be reassigned
+func (e *Environment) FrozenBinding(name string) bool {
+	for env := e; env != nil; env = env.outer {
+		if _, ok := env.lookup(name); ok {
+			return env.frozen
+		}
+	}
+	return false
+}
+
+// This function returns the environment this one encloses, or nil if it is the outermost
+func (e *Environment) Outer() *Environment {
+	return e.outer
+}
+
+// This function lists the names bound directly in this environment, not counting enclosing ones, in alphabetical order
+func (e *Environment) Names() []string {
+	e.mu.RLock()
+	names := make([]string, 0, len(e.store))
+	for name := range e.store {
+		names = append(names, name)
+	}
+	e.mu.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
 	return &Environment{store: s, outer: nil}
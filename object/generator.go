@@ -0,0 +1,167 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrGeneratorRunning is returned by Next when the generator is already running, such as when its body asks for its own next value
+var ErrGeneratorRunning = errors.New("the generator is already running")
+
+// the body of a generator, which hands values to the caller of Next through y and returns an error object if it fails
+type GeneratorBody func(ctx context.Context, y *Yielder) Object
+
+// the struct needed for holding the iterator returned by calling a generator function
+// the body runs on its own goroutine, which is suspended at each yield until the next value is requested
+// a generator which is closed, or abandoned and garbage collected, stops its goroutine
+type Generator struct {
+	state *generatorState
+}
+
+// the state shared by a generator and the goroutine running its body, which must not refer to the Generator itself
+// so that an abandoned generator can be garbage collected while its body is suspended
+type generatorState struct {
+	body   GeneratorBody
+	resume chan context.Context
+	steps  chan generatorStep
+	stop   chan struct{}
+	owner  <-chan struct{}
+	once   sync.Once
+
+	mu       sync.Mutex
+	started  bool
+	finished bool
+}
+
+// a value handed from the body to the caller of Next, or the body's result once it has finished
+type generatorStep struct {
+	value    Object
+	finished bool
+}
+
+// owner is closed once the owner of the generator is finished with it, which stops the generator like Close
+func NewGenerator(owner <-chan struct{}, body GeneratorBody) *Generator {
+	generator := &Generator{state: &generatorState{
+		body:   body,
+		resume: make(chan context.Context),
+		steps:  make(chan generatorStep),
+		stop:   make(chan struct{}),
+		owner:  owner,
+	}}
+
+	runtime.SetFinalizer(generator, func(g *Generator) { g.state.close() })
+
+	return generator
+}
+
+// Next runs the body until it yields, returning the yielded value. It returns nil once the body has finished,
+// or the error object the body failed with. The body runs with ctx until it next yields.
+// A generator produces one value at a time, so Next fails with ErrGeneratorRunning rather than waiting if it is already running.
+func (g *Generator) Next(ctx context.Context) (Object, error) {
+	s := g.state
+	if !s.mu.TryLock() {
+		return nil, ErrGeneratorRunning
+	}
+	defer s.mu.Unlock()
+
+	if s.finished || s.stopped() {
+		return nil, nil
+	}
+
+	if !s.started {
+		s.started = true
+		go s.run(ctx)
+	} else {
+		select {
+		case s.resume <- ctx:
+		case <-s.stop:
+			s.finished = true
+			return nil, nil
+		case <-s.owner:
+			s.finished = true
+			return nil, nil
+		}
+	}
+
+	select {
+	case step := <-s.steps:
+		s.finished = step.finished
+		return step.value, nil
+	case <-s.stop:
+		s.finished = true
+		return nil, nil
+	case <-s.owner:
+		s.finished = true
+		return nil, nil
+	case <-ctx.Done():
+		// The body may be part way through a step, so it cannot be resumed later
+		s.finished = true
+		s.close()
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the generator. Its body is stopped at the yield it is suspended at, and later calls to Next return nil.
+func (g *Generator) Close() {
+	g.state.close()
+}
+
+func (g *Generator) Inspect() string  { return "generator" }
+func (g *Generator) Type() ObjectType { return GENERATOR_OBJ }
+
+func (s *generatorState) close() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// This method reports whether the generator has been closed, either directly or by its owner
+func (s *generatorState) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	case <-s.owner:
+		return true
+	default:
+		return false
+	}
+}
+
+// This method runs the body on the generator's goroutine and hands its result to the caller of Next
+func (s *generatorState) run(ctx context.Context) {
+	result := s.body(ctx, &Yielder{state: s})
+
+	select {
+	case s.steps <- generatorStep{value: result, finished: true}:
+	case <-s.stop:
+	case <-s.owner:
+	}
+}
+
+// Yielder is used by the body of a generator to hand values to the caller of Next
+type Yielder struct {
+	state *generatorState
+}
+
+// Yield hands value to the caller of Next and suspends the body until the next value is requested, returning the
+// context of the new caller. It returns false if the generator was closed, in which case the body must stop.
+func (y *Yielder) Yield(value Object) (context.Context, bool) {
+	s := y.state
+
+	select {
+	case s.steps <- generatorStep{value: value}:
+	case <-s.stop:
+		return nil, false
+	case <-s.owner:
+		return nil, false
+	}
+
+	select {
+	case ctx := <-s.resume:
+		return ctx, true
+	case <-s.stop:
+		return nil, false
+	case <-s.owner:
+		return nil, false
+	}
+}
@@ -2,8 +2,10 @@ package object
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/armansandhu/monkey_interpreter/ast"
 )
@@ -19,6 +21,18 @@ const (
 	FUNCTION_OBJ     = "FUNCTION"
 	STRING_OBJ       = "STRING"
 	BUILTIN_OBJ      = "BUILTIN"
+	TUPLE_OBJ        = "TUPLE"
+	COMPOSITION_OBJ  = "COMPOSITION"
+	STRUCT_DEF_OBJ   = "STRUCT_DEFINITION"
+	STRUCT_OBJ       = "STRUCT"
+	BOUND_METHOD_OBJ = "BOUND_METHOD"
+	CLASS_OBJ        = "CLASS"
+	INSTANCE_OBJ     = "INSTANCE"
+	SUPER_OBJ        = "SUPER"
+	MODULE_OBJ       = "MODULE"
+	TASK_OBJ         = "TASK"
+	CHANNEL_OBJ      = "CHANNEL"
+	GENERATOR_OBJ    = "GENERATOR"
 )
 
 // every value will be wrapped inside a struct
@@ -57,18 +71,31 @@ type ReturnValue struct {
 func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
 func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 
+// the kinds of error which embedding code may need to tell apart from ordinary runtime errors
+type ErrorKind string
+
+const (
+	CANCELLED_ERROR          ErrorKind = "CANCELLED"
+	RESOURCE_EXHAUSTED_ERROR ErrorKind = "RESOURCE_EXHAUSTED"
+	PERMISSION_DENIED_ERROR  ErrorKind = "PERMISSION_DENIED"
+)
+
 // the struct needed to handle internal errors
+// Kind is empty for ordinary runtime errors
 type Error struct {
 	Message string
+	Kind    ErrorKind
 }
 
 func (e *Error) Inspect() string  { return e.Message }
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
 
+// Generator is set for functions containing a yield, whose calls return a Generator instead of running the body
 type Function struct {
 	Parameters []*ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
+	Generator  bool
 }
 
 func (f *Function) Inspect() string {
@@ -90,6 +117,16 @@ func (f *Function) Inspect() string {
 }
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
 
+// the struct needed for holding the function produced by composing two functions with >>
+// calling it applies First to the arguments and then Second to the result
+type Composition struct {
+	First  Object
+	Second Object
+}
+
+func (c *Composition) Inspect() string  { return c.First.Inspect() + " >> " + c.Second.Inspect() }
+func (c *Composition) Type() ObjectType { return COMPOSITION_OBJ }
+
 type String struct {
 	Value string
 }
@@ -99,9 +136,197 @@ func (s *String) Type() ObjectType { return STRING_OBJ }
 
 type BuiltInFunction func(args ...Object) Object
 
+// a built-in function which also receives the context of the evaluation calling it, so that it can stop blocking
+// when the evaluation is cancelled
+type ContextBuiltInFunction func(ctx context.Context, args ...Object) Object
+
+// ContextFunction is called instead of Function when it is set
 type BuiltIn struct {
-	Function BuiltInFunction
+	Function        BuiltInFunction
+	ContextFunction ContextBuiltInFunction
 }
 
 func (b *BuiltIn) Inspect() string  { return "Built-In Function" }
 func (b *BuiltIn) Type() ObjectType { return BUILTIN_OBJ }
+
+// the struct needed for holding a method together with the value it was accessed on, such as "abc".upper
+// calling it passes the receiver as the method's first argument
+// Class is only set for methods of user-defined classes, and is the class which defines Method
+type BoundMethod struct {
+	Receiver Object
+	Name     string
+	Method   Object
+	Class    *Class
+}
+
+func (bm *BoundMethod) Inspect() string  { return bm.Receiver.Inspect() + "." + bm.Name }
+func (bm *BoundMethod) Type() ObjectType { return BOUND_METHOD_OBJ }
+
+type Tuple struct {
+	Elements []Object
+}
+
+func (t *Tuple) Inspect() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range t.Elements {
+		elements = append(elements, e.Inspect())
+	}
+
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+func (t *Tuple) Type() ObjectType { return TUPLE_OBJ }
+
+// the struct needed for holding a struct declaration, which lists the names of its fields in order
+type StructDefinition struct {
+	Name   string
+	Fields []string
+}
+
+func (sd *StructDefinition) Inspect() string {
+	return "struct " + sd.Name + " { " + strings.Join(sd.Fields, ", ") + " }"
+}
+func (sd *StructDefinition) Type() ObjectType { return STRUCT_DEF_OBJ }
+
+// HasField reports whether the struct declaration contains a field with the given name
+func (sd *StructDefinition) HasField(name string) bool {
+	for _, field := range sd.Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// the struct needed for holding an instance of a declared struct
+// structs are values, so updating a field produces a copy rather than changing the original
+type Struct struct {
+	Definition *StructDefinition
+	Fields     map[string]Object
+}
+
+func (s *Struct) Inspect() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, name := range s.Definition.Fields {
+		fields = append(fields, name+": "+s.Fields[name].Inspect())
+	}
+
+	out.WriteString(s.Definition.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+func (s *Struct) Type() ObjectType { return STRUCT_OBJ }
+
+// the struct needed for holding a class declaration
+type Class struct {
+	Name    string
+	Parent  *Class
+	Methods map[string]*Function
+}
+
+func (c *Class) Inspect() string {
+	if c.Parent != nil {
+		return "class " + c.Name + "(" + c.Parent.Name + ")"
+	}
+	return "class " + c.Name
+}
+func (c *Class) Type() ObjectType { return CLASS_OBJ }
+
+// FindMethod looks up a method on the class and then on each of its ancestors in turn.
+// It returns the method along with the class that defines it.
+func (c *Class) FindMethod(name string) (*Function, *Class) {
+	for class := c; class != nil; class = class.Parent {
+		if method, ok := class.Methods[name]; ok {
+			return method, class
+		}
+	}
+	return nil, nil
+}
+
+// the struct needed for holding an instance of a class
+// unlike structs, the fields of an instance can be assigned to and new fields can be added at any time
+// GetField and SetField are safe for concurrent use, so instances can be shared with spawned tasks
+type Instance struct {
+	Class  *Class
+	Fields map[string]Object
+	order  []string
+	mu     sync.RWMutex
+}
+
+func NewInstance(class *Class) *Instance {
+	return &Instance{Class: class, Fields: make(map[string]Object)}
+}
+
+// GetField looks up a field of the instance
+func (i *Instance) GetField(name string) (Object, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	value, ok := i.Fields[name]
+	return value, ok
+}
+
+// SetField assigns a field of the instance, remembering the order in which fields were first added
+func (i *Instance) SetField(name string, value Object) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.Fields[name]; !ok {
+		i.order = append(i.order, name)
+	}
+	i.Fields[name] = value
+}
+
+// FieldNames returns the names of the instance's fields in the order they were first added
+func (i *Instance) FieldNames() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]string(nil), i.order...)
+}
+
+func (i *Instance) Inspect() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, name := range i.FieldNames() {
+		value, _ := i.GetField(name)
+		fields = append(fields, name+": "+value.Inspect())
+	}
+
+	out.WriteString(i.Class.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
+
+// the struct needed for holding the value of 'super' inside a method
+// methods accessed through it are looked up starting at Class and are bound to Receiver
+type Super struct {
+	Class    *Class
+	Receiver *Instance
+}
+
+func (s *Super) Inspect() string  { return "super" }
+func (s *Super) Type() ObjectType { return SUPER_OBJ }
+
+// the struct needed for holding an imported module
+// Name is the path it was imported by and Exports holds the values of its exported declarations
+type Module struct {
+	Name    string
+	Path    string
+	Exports map[string]Object
+}
+
+func (m *Module) Inspect() string  { return "module " + m.Name }
+func (m *Module) Type() ObjectType { return MODULE_OBJ }
@@ -0,0 +1,30 @@
+package object
+
+// BinaryOperand is implemented by objects which support infix operators such as +, - or <.
+// Operate is called when the object is the left operand and returns the result of applying the operator with other on the right.
+// It should return nil when the operator or the type of other is not supported, so that the usual error can be reported,
+// and an *Error for failures such as adding amounts in different currencies.
+type BinaryOperand interface {
+	Object
+	Operate(operator string, other Object) Object
+}
+
+// ReflectedBinaryOperand is implemented by objects which also support infix operators when they are the right operand,
+// such as 2 * vector. OperateReflected receives the left operand as other and follows the same rules as Operate.
+type ReflectedBinaryOperand interface {
+	Object
+	OperateReflected(operator string, other Object) Object
+}
+
+// Equaler is implemented by objects which define their own equality for == and !=.
+// Without it two objects are only equal if they are the same object.
+type Equaler interface {
+	Object
+	Equals(other Object) bool
+}
+
+// Stringer is implemented by objects which can be converted to a string, allowing them to be concatenated with strings using +.
+type Stringer interface {
+	Object
+	String() string
+}
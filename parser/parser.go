@@ -13,6 +13,12 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // x = y
+	LAMBDA      // x => x
+	PIPELINE    // a |> f
+	COMPOSE     // f >> g
+	TERNARY     // a ? b : c
+	COALESCE    // a ?? b
 	EQUALS      // ==
 	LESSGREATER // > or <
 	SUM         // +
@@ -23,6 +29,16 @@ const (
 
 // Precedence Table - associates token types with their precedence
 var precedences = map[token.TokenType]int{
+	token.ASSIGN:   ASSIGN,
+	token.PLUS_EQ:  ASSIGN,
+	token.MINUS_EQ: ASSIGN,
+	token.STAR_EQ:  ASSIGN,
+	token.SLASH_EQ: ASSIGN,
+	token.ARROW:    LAMBDA,
+	token.PIPELINE: PIPELINE,
+	token.COMPOSE:  COMPOSE,
+	token.QUESTION: TERNARY,
+	token.COALESCE: COALESCE,
 	token.EQ:       EQUALS,
 	token.NOT_EQ:   EQUALS,
 	token.LT:       LESSGREATER,
@@ -32,12 +48,15 @@ var precedences = map[token.TokenType]int{
 	token.SLASH:    PRODUCT,
 	token.ASTERISK: PRODUCT,
 	token.LPAREN:   CALL,
+	token.LBRACE:   CALL,
+	token.DOT:      CALL,
 }
 
 // Parser data structure:
 // l - pointer to an instance of the lexer
 // currToken - pointer to the current token being processed
 // peekToken - a pointer to the next tken that will be processed
+// lookahead - the tokens after the peekToken which have already been read from the lexer
 // errors - a slice containing all the errors encountered as a part of the parsing process - debug only
 // prefixParseFns - a map containing all the prefix parsing functions associated with a TokenType
 // infixParseFns - a map containing all the infix parsing functions associated with a TokenType
@@ -46,9 +65,13 @@ type Parser struct {
 
 	currToken token.Token
 	peekToken token.Token
+	lookahead []token.Token
 
 	errors []string
 
+	// one entry for each function whose body is being parsed, recording whether it contains a yield
+	functions []bool
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
@@ -81,6 +104,11 @@ func New(l *lexer.Lexer) *Parser {
 	prsr.registerPrefix(token.IF, prsr.parseIfExpression)
 	prsr.registerPrefix(token.FUNCTION, prsr.parseFunctionLiteral)
 	prsr.registerPrefix(token.STRING, prsr.parseStringLiteral)
+	prsr.registerPrefix(token.MATCH, prsr.parseMatchExpression)
+	prsr.registerPrefix(token.SPAWN, prsr.parseSpawnExpression)
+	prsr.registerPrefix(token.SELECT, prsr.parseSelectExpression)
+	prsr.registerPrefix(token.YIELD, prsr.parseYieldExpression)
+	prsr.registerPrefix(token.NULL, prsr.parseNullLiteral)
 
 	// Initialize the infix parse map and register parsing functions for all the infix operators
 	prsr.infixParseFns = make(map[token.TokenType]infixParseFn)
@@ -93,6 +121,18 @@ func New(l *lexer.Lexer) *Parser {
 	prsr.registerInfix(token.LT, prsr.parseInfixExpression)
 	prsr.registerInfix(token.GT, prsr.parseInfixExpression)
 	prsr.registerInfix(token.LPAREN, prsr.parseCallExpresssion)
+	prsr.registerInfix(token.QUESTION, prsr.parseConditionalExpression)
+	prsr.registerInfix(token.COALESCE, prsr.parseCoalesceExpression)
+	prsr.registerInfix(token.PIPELINE, prsr.parsePipelineExpression)
+	prsr.registerInfix(token.ARROW, prsr.parseArrowFunction)
+	prsr.registerInfix(token.LBRACE, prsr.parseStructLiteral)
+	prsr.registerInfix(token.DOT, prsr.parseSelectorExpression)
+	prsr.registerInfix(token.ASSIGN, prsr.parseAssignExpression)
+	prsr.registerInfix(token.PLUS_EQ, prsr.parseAssignExpression)
+	prsr.registerInfix(token.MINUS_EQ, prsr.parseAssignExpression)
+	prsr.registerInfix(token.STAR_EQ, prsr.parseAssignExpression)
+	prsr.registerInfix(token.SLASH_EQ, prsr.parseAssignExpression)
+	prsr.registerInfix(token.COMPOSE, prsr.parseInfixExpression)
 
 	return prsr
 }
@@ -100,9 +140,43 @@ func New(l *lexer.Lexer) *Parser {
 // This helper function advances the currToken and peekToken pointers.
 func (p *Parser) nextToken() {
 	p.currToken = p.peekToken
+
+	if len(p.lookahead) > 0 {
+		p.peekToken = p.lookahead[0]
+		p.lookahead = p.lookahead[1:]
+		return
+	}
 	p.peekToken = p.lxr.NextToken()
 }
 
+// This helper function returns the token n places after the peekToken without advancing past it
+func (p *Parser) peekAhead(n int) token.Token {
+	for len(p.lookahead) < n {
+		p.lookahead = append(p.lookahead, p.lxr.NextToken())
+	}
+	return p.lookahead[n-1]
+}
+
+// This helper function reports whether the '{' in the peekToken opens a struct literal. That is only the case after the
+// name of a struct, when the '{' is followed by a field such as 'x:', by '..' or by the '}' of an empty struct.
+// Any other '{' after an expression is left for the enclosing construct.
+func (p *Parser) structLiteralAhead(name ast.Expression) bool {
+	switch name.(type) {
+	case *ast.Identifier, *ast.SelectorExpression:
+	default:
+		return false
+	}
+
+	switch p.peekAhead(1).Type {
+	case token.SPREAD, token.RBRACE:
+		return true
+	case token.IDENTIFIERS:
+		return p.peekAhead(2).Type == token.COLON
+	default:
+		return false
+	}
+}
+
 // This function is responsible for creating our AST.
 func (p *Parser) ParseProgram() *ast.Program {
 	// Create the 'root' of the AST
@@ -134,6 +208,17 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.STRUCT:
+		return p.parseStructStatement()
+	case token.CLASS:
+		return p.parseClassStatement()
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
+	case token.SEMICOLON:
+		// An empty statement, such as a stray ';'
+		return nil
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -144,11 +229,21 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	// Create a LetStatement struct
 	stmt := &ast.LetStatement{Token: p.currToken}
 
-	if !p.expectPeek(token.IDENTIFIERS) {
-		return nil
-	}
+	// A let statement followed by a '(' destructures its value using a tuple pattern
+	if p.peekTokenIs(token.LPAREN) {
+		p.nextToken()
 
-	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+		stmt.Pattern = p.parsePattern()
+		if stmt.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.expectPeek(token.IDENTIFIERS) {
+			return nil
+		}
+
+		stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+	}
 
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
@@ -158,9 +253,8 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 
 	stmt.Value = p.parseExpression(LOWEST)
 
-	// Skip expression parsing for now
-	if p.peekTokenIs(token.SEMICOLON) {
-		p.nextToken()
+	if !p.expectStatementEnd() {
+		return nil
 	}
 
 	return stmt
@@ -171,12 +265,18 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	// Create a ReturnStatement struct
 	stmt := &ast.ReturnStatement{Token: p.currToken}
 
+	// A bare return has no value
+	if p.peekTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) {
+		p.expectStatementEnd()
+		return stmt
+	}
+
 	p.nextToken()
 
 	stmt.ReturnValue = p.parseExpression(LOWEST)
 
-	if p.peekTokenIs(token.SEMICOLON) {
-		p.nextToken()
+	if !p.expectStatementEnd() {
+		return nil
 	}
 
 	return stmt
@@ -189,11 +289,28 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 
 	stmt.Expression = p.parseExpression(LOWEST)
 
+	if !p.expectStatementEnd() {
+		return nil
+	}
+
+	return stmt
+}
+
+// This method is the assertion function for the end of a statement. A statement ends with a semicolon, which is either written out
+// or inserted by the lexer at the end of a line, or directly before a closing brace or the end of the input.
+func (p *Parser) expectStatementEnd() bool {
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
+		return true
 	}
 
-	return stmt
+	if p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) {
+		return true
+	}
+
+	msg := fmt.Sprintf("Expected end of statement, instead received '%s'!", p.peekToken.Type)
+	p.errors = append(p.errors, msg)
+	return false
 }
 
 func (p *Parser) currTokenIs(token token.TokenType) bool {
@@ -254,6 +371,10 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 			return leftExp
 		}
 
+		if p.peekTokenIs(token.LBRACE) && !p.structLiteralAhead(leftExp) {
+			return leftExp
+		}
+
 		p.nextToken()
 
 		leftExp = infix(leftExp)
@@ -329,21 +450,166 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
+// This method parses the ternary conditional. Both the ternary and the null-coalescing operator are right associative,
+// so a ? b : c ? d : e groups as a ? b : (c ? d : e).
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	expression := &ast.ConditionalExpression{Token: p.currToken, Condition: condition}
+
+	p.nextToken()
+	expression.Consequence = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+
+	p.nextToken()
+	expression.Alternative = p.parseExpression(TERNARY - 1)
+
+	return expression
+}
+
+func (p *Parser) parseCoalesceExpression(left ast.Expression) ast.Expression {
+	expression := &ast.InfixExpression{
+		Token:    p.currToken,
+		Operator: p.currToken.Literal,
+		Left:     left,
+	}
+
+	p.nextToken()
+	expression.Right = p.parseExpression(COALESCE - 1)
+
+	return expression
+}
+
+// This method parses the pipeline operator. The left operand becomes the first argument of the call on the right,
+// so x |> f(y) is parsed as f(x, y) and x |> f as f(x).
+func (p *Parser) parsePipelineExpression(left ast.Expression) ast.Expression {
+	pipeToken := p.currToken
+
+	p.nextToken()
+	right := p.parseExpression(PIPELINE)
+
+	if call, ok := right.(*ast.CallExpression); ok {
+		arguments := append([]ast.Expression{left}, call.Arguments...)
+		return &ast.CallExpression{Token: call.Token, Function: call.Function, Arguments: arguments}
+	}
+
+	if right == nil {
+		return nil
+	}
+
+	return &ast.CallExpression{Token: pipeToken, Function: right, Arguments: []ast.Expression{left}}
+}
+
+// This method parses an arrow function into a function literal. The parameters on the left of '=>' are either a single identifier
+// or a parenthesised list of identifiers, which the grouped expression parser hands over as an identifier or a tuple.
+// The body is either a block or a single expression which becomes the function's implicit return value.
+func (p *Parser) parseArrowFunction(left ast.Expression) ast.Expression {
+	literal := &ast.FunctionLiteral{Token: token.Token{Type: token.FUNCTION, Literal: "fn"}}
+
+	literal.Parameters = p.parseArrowFunctionParameters(left)
+	if literal.Parameters == nil {
+		return nil
+	}
+
+	p.enterFunction()
+	defer func() { literal.Generator = p.exitFunction() }()
+
+	if p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		literal.Body = p.parseBlockStatement()
+		return literal
+	}
+
+	p.nextToken()
+
+	body := &ast.ExpressionStatement{Token: p.currToken, Expression: p.parseExpression(LOWEST)}
+	literal.Body = &ast.BlockStatement{Token: body.Token, Statements: []ast.Statement{body}}
+
+	return literal
+}
+
+func (p *Parser) parseArrowFunctionParameters(left ast.Expression) []*ast.Identifier {
+	if left == nil {
+		return nil
+	}
+
+	elements := []ast.Expression{left}
+	if tuple, ok := left.(*ast.TupleLiteral); ok {
+		elements = tuple.Elements
+	}
+
+	identifiers := []*ast.Identifier{}
+
+	for _, element := range elements {
+		identifier, ok := element.(*ast.Identifier)
+		if !ok {
+			msg := fmt.Sprintf("Invalid Arrow Function Parameter! Received '%s'", element)
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		identifiers = append(identifiers, identifier)
+	}
+
+	return identifiers
+}
+
+func (p *Parser) parseNullLiteral() ast.Expression {
+	return &ast.NullLiteral{Token: p.currToken}
+}
+
 // This method parses a boolean
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.Boolean{Token: p.currToken, Value: p.currTokenIs(token.TRUE)}
 }
 
+// This method parses a grouped expression, or a tuple literal if the parentheses hold a comma separated list
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	start := p.currToken
+
+	// Empty parentheses are only valid as the parameter list of an arrow function, such as () => 1
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+
+		if !p.peekTokenIs(token.ARROW) {
+			p.peekError(token.ARROW)
+			return nil
+		}
+
+		return &ast.TupleLiteral{Token: start, Elements: []ast.Expression{}}
+	}
+
 	p.nextToken()
 
 	expression := p.parseExpression(LOWEST)
 
+	if !p.peekTokenIs(token.COMMA) {
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+
+		return expression
+	}
+
+	tuple := &ast.TupleLiteral{Token: start, Elements: []ast.Expression{expression}}
+
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+
+		// A trailing comma allows single element tuples such as (1,)
+		if p.peekTokenIs(token.RPAREN) {
+			break
+		}
+
+		p.nextToken()
+		tuple.Elements = append(tuple.Elements, p.parseExpression(LOWEST))
+	}
+
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
 
-	return expression
+	return tuple
 }
 
 // This method parses an if expression
@@ -371,6 +637,18 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	if p.peekTokenIs(token.ELSE) {
 		p.nextToken()
 
+		// An 'else if' is parsed as an alternative block holding a single nested if expression
+		if p.peekTokenIs(token.IF) {
+			p.nextToken()
+
+			block := &ast.BlockStatement{Token: p.currToken}
+			statement := &ast.ExpressionStatement{Token: p.currToken, Expression: p.parseIfExpression()}
+			block.Statements = []ast.Statement{statement}
+
+			expression.Alternative = block
+			return expression
+		}
+
 		if !p.expectPeek(token.LBRACE) {
 			return nil
 		}
@@ -412,11 +690,48 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 		return nil
 	}
 
+	p.enterFunction()
 	literal.Body = p.parseBlockStatement()
+	literal.Generator = p.exitFunction()
 
 	return literal
 }
 
+// This method records that the body of a function is about to be parsed, so that a yield inside it can mark it as a generator
+func (p *Parser) enterFunction() {
+	p.functions = append(p.functions, false)
+}
+
+// This method finishes parsing the body of a function, reporting whether it contains a yield.
+// A yield inside a nested function makes only the nested function a generator.
+func (p *Parser) exitFunction() bool {
+	generator := p.functions[len(p.functions)-1]
+	p.functions = p.functions[:len(p.functions)-1]
+	return generator
+}
+
+// This method parses a yield, which may only appear inside a function and turns that function into a generator.
+// The yielded value is optional, so a yield at the end of an expression yields null.
+func (p *Parser) parseYieldExpression() ast.Expression {
+	expression := &ast.YieldExpression{Token: p.currToken}
+
+	if len(p.functions) == 0 {
+		p.errors = append(p.errors, "Invalid Yield! yield can only be used inside a function")
+		return nil
+	}
+	p.functions[len(p.functions)-1] = true
+
+	switch p.peekToken.Type {
+	case token.SEMICOLON, token.RBRACE, token.RPAREN, token.COMMA, token.EOF:
+		return expression
+	}
+
+	p.nextToken()
+	expression.Value = p.parseExpression(LOWEST)
+
+	return expression
+}
+
 func (p *Parser) parseFunctionParameters() []*ast.Identifier {
 	identifiers := []*ast.Identifier{}
 
@@ -477,3 +792,543 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{Token: p.currToken, Value: p.currToken.Literal}
 }
+
+// This method parses a match expression of the form: match (value) { pattern | pattern if guard => body, ... }
+func (p *Parser) parseMatchExpression() ast.Expression {
+	expression := &ast.MatchExpression{Token: p.currToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+
+	expression.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Arms = []*ast.MatchArm{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		expression.Arms = append(expression.Arms, arm)
+
+		// Arms are separated by commas, and a trailing comma is permitted
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return expression
+}
+
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{Token: p.currToken}
+
+	arm.Pattern = p.parsePattern()
+	if arm.Pattern == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.IF) {
+		p.nextToken()
+		p.nextToken()
+		// The guard stops before '=>' so that the arrow is not mistaken for an arrow function
+		arm.Guard = p.parseExpression(LAMBDA)
+	}
+
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		arm.Body = p.parseBlockStatement()
+		return arm
+	}
+
+	p.nextToken()
+
+	body := &ast.ExpressionStatement{Token: p.currToken, Expression: p.parseExpression(LOWEST)}
+	arm.Body = &ast.BlockStatement{Token: body.Token, Statements: []ast.Statement{body}}
+
+	return arm
+}
+
+// This method parses a pattern, which is one or more primary patterns separated by '|'
+func (p *Parser) parsePattern() ast.Pattern {
+	start := p.currToken
+
+	pattern := p.parsePrimaryPattern()
+	if pattern == nil || !p.peekTokenIs(token.PIPE) {
+		return pattern
+	}
+
+	alternative := &ast.AlternativePattern{Token: start, Alternatives: []ast.Pattern{pattern}}
+
+	for p.peekTokenIs(token.PIPE) {
+		p.nextToken()
+		p.nextToken()
+
+		pattern := p.parsePrimaryPattern()
+		if pattern == nil {
+			return nil
+		}
+		alternative.Alternatives = append(alternative.Alternatives, pattern)
+	}
+
+	return alternative
+}
+
+// This method parses a single pattern. A pattern is either a wildcard '_', an identifier which binds the matched value,
+// a literal, a negative integer, or a parenthesised tuple of nested patterns.
+func (p *Parser) parsePrimaryPattern() ast.Pattern {
+	switch p.currToken.Type {
+	case token.IDENTIFIERS:
+		if p.currToken.Literal == "_" {
+			return &ast.WildcardPattern{Token: p.currToken}
+		}
+		return &ast.IdentifierPattern{Token: p.currToken, Name: &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}}
+	case token.INT:
+		return p.parseLiteralPattern(p.parseIntegerLiteral)
+	case token.STRING:
+		return p.parseLiteralPattern(p.parseStringLiteral)
+	case token.TRUE, token.FALSE:
+		return p.parseLiteralPattern(p.parseBoolean)
+	case token.NULL:
+		return p.parseLiteralPattern(p.parseNullLiteral)
+	case token.MINUS:
+		if !p.peekTokenIs(token.INT) {
+			p.peekError(token.INT)
+			return nil
+		}
+		return p.parseLiteralPattern(p.parsePrefixExpression)
+	case token.LPAREN:
+		return p.parseTuplePattern()
+	default:
+		msg := fmt.Sprintf("Invalid Pattern! Received '%s'", p.currToken.Literal)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+}
+
+// This helper function wraps the literal produced by a prefix parsing function in a LiteralPattern
+func (p *Parser) parseLiteralPattern(parse prefixParseFn) ast.Pattern {
+	pattern := &ast.LiteralPattern{Token: p.currToken}
+
+	pattern.Value = parse()
+	if pattern.Value == nil {
+		return nil
+	}
+
+	return pattern
+}
+
+func (p *Parser) parseTuplePattern() ast.Pattern {
+	start := p.currToken
+
+	p.nextToken()
+
+	pattern := p.parsePattern()
+	if pattern == nil {
+		return nil
+	}
+
+	// Without a comma the parentheses only group a single pattern
+	if !p.peekTokenIs(token.COMMA) {
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+		return pattern
+	}
+
+	tuple := &ast.TuplePattern{Token: start, Elements: []ast.Pattern{pattern}}
+
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.RPAREN) {
+			break
+		}
+
+		p.nextToken()
+
+		pattern := p.parsePattern()
+		if pattern == nil {
+			return nil
+		}
+		tuple.Elements = append(tuple.Elements, pattern)
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return tuple
+}
+
+// This method parses a struct declaration of the form: struct Name { field, field }
+func (p *Parser) parseStructStatement() *ast.StructStatement {
+	stmt := &ast.StructStatement{Token: p.currToken}
+
+	if !p.expectPeek(token.IDENTIFIERS) {
+		return nil
+	}
+
+	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Fields = []*ast.Identifier{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		if !p.expectPeek(token.IDENTIFIERS) {
+			return nil
+		}
+
+		stmt.Fields = append(stmt.Fields, &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal})
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	p.nextToken()
+
+	if !p.expectStatementEnd() {
+		return nil
+	}
+
+	return stmt
+}
+
+// This method parses the construction of a struct, such as Point { x: 1, y: 2 } or Point { x: 3, ..p }.
+// It is registered as an infix function on '{' so that the struct's name is the left expression, and is only called
+// when structLiteralAhead has found a struct literal.
+func (p *Parser) parseStructLiteral(name ast.Expression) ast.Expression {
+	literal := &ast.StructLiteral{Token: p.currToken, Name: name, Fields: []*ast.StructField{}}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		// The base struct to copy from must be the final entry
+		if p.peekTokenIs(token.SPREAD) {
+			p.nextToken()
+			p.nextToken()
+			literal.Base = p.parseExpression(LOWEST)
+
+			if p.peekTokenIs(token.COMMA) {
+				p.nextToken()
+			}
+			break
+		}
+
+		if !p.expectPeek(token.IDENTIFIERS) {
+			return nil
+		}
+
+		field := &ast.StructField{Name: &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}}
+
+		if !p.expectPeek(token.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		field.Value = p.parseExpression(LOWEST)
+		literal.Fields = append(literal.Fields, field)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return literal
+}
+
+func (p *Parser) parseSelectorExpression(left ast.Expression) ast.Expression {
+	expression := &ast.SelectorExpression{Token: p.currToken, Left: left}
+
+	if !p.expectPeek(token.IDENTIFIERS) {
+		return nil
+	}
+
+	expression.Field = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+
+	return expression
+}
+
+// This method parses an assignment to a variable or to a field. Assignment is right associative, so a = b = c assigns c to both.
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	switch target.(type) {
+	case *ast.Identifier, *ast.SelectorExpression:
+	default:
+		msg := fmt.Sprintf("Invalid Assignment Target! Received '%s'", target)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+
+	expression := &ast.AssignExpression{Token: p.currToken, Target: target, Operator: p.currToken.Literal}
+
+	p.nextToken()
+	expression.Value = p.parseExpression(ASSIGN - 1)
+
+	return expression
+}
+
+// This method parses a class declaration of the form: class Name(Parent) { method(self, a) { ... } ... }
+// The parent class is optional, and every method receives the instance it is called on as its first parameter.
+func (p *Parser) parseClassStatement() *ast.ClassStatement {
+	stmt := &ast.ClassStatement{Token: p.currToken}
+
+	if !p.expectPeek(token.IDENTIFIERS) {
+		return nil
+	}
+
+	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+
+	if p.peekTokenIs(token.LPAREN) {
+		p.nextToken()
+		p.nextToken()
+
+		stmt.Parent = p.parseExpression(LOWEST)
+
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Methods = []*ast.ClassMethod{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		// Methods may be separated by semicolons or newlines
+		if p.peekTokenIs(token.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+
+		if !p.expectPeek(token.IDENTIFIERS) {
+			return nil
+		}
+
+		method := &ast.ClassMethod{Name: &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}}
+		method.Function = &ast.FunctionLiteral{Token: token.Token{Type: token.FUNCTION, Literal: "fn"}}
+
+		if !p.expectPeek(token.LPAREN) {
+			return nil
+		}
+
+		method.Function.Parameters = p.parseFunctionParameters()
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+
+		p.enterFunction()
+		method.Function.Body = p.parseBlockStatement()
+		method.Function.Generator = p.exitFunction()
+		stmt.Methods = append(stmt.Methods, method)
+	}
+
+	p.nextToken()
+
+	if !p.expectStatementEnd() {
+		return nil
+	}
+
+	return stmt
+}
+
+// This method parses an import of the form: import "path/to/module" as name
+func (p *Parser) parseImportStatement() *ast.ImportStatement {
+	stmt := &ast.ImportStatement{Token: p.currToken}
+
+	if !p.expectPeek(token.STRING) {
+		return nil
+	}
+
+	stmt.Path = p.currToken.Literal
+
+	if p.peekTokenIs(token.AS) {
+		p.nextToken()
+
+		if !p.expectPeek(token.IDENTIFIERS) {
+			return nil
+		}
+
+		stmt.Alias = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+	}
+
+	if !p.expectStatementEnd() {
+		return nil
+	}
+
+	return stmt
+}
+
+// This method parses an export, which must be followed by a let, struct or class declaration
+func (p *Parser) parseExportStatement() *ast.ExportStatement {
+	stmt := &ast.ExportStatement{Token: p.currToken}
+
+	p.nextToken()
+
+	switch p.currToken.Type {
+	case token.LET:
+		if declaration := p.parseLetStatement(); declaration != nil {
+			stmt.Statement = declaration
+		}
+	case token.STRUCT:
+		if declaration := p.parseStructStatement(); declaration != nil {
+			stmt.Statement = declaration
+		}
+	case token.CLASS:
+		if declaration := p.parseClassStatement(); declaration != nil {
+			stmt.Statement = declaration
+		}
+	default:
+		msg := fmt.Sprintf("Only let, struct and class declarations can be exported! Received '%s'", p.currToken.Literal)
+		p.errors = append(p.errors, msg)
+	}
+
+	if stmt.Statement == nil {
+		return nil
+	}
+
+	return stmt
+}
+
+// This method parses spawn followed by the function call or function to run in a new task
+func (p *Parser) parseSpawnExpression() ast.Expression {
+	expression := &ast.SpawnExpression{Token: p.currToken}
+
+	p.nextToken()
+
+	expression.Call = p.parseExpression(PREFIX)
+	if expression.Call == nil {
+		return nil
+	}
+
+	return expression
+}
+
+// This method parses a select expression, whose cases are separated by commas like the arms of a match expression
+func (p *Parser) parseSelectExpression() ast.Expression {
+	expression := &ast.SelectExpression{Token: p.currToken}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Cases = []*ast.SelectCase{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		selectCase := p.parseSelectCase()
+		if selectCase == nil {
+			return nil
+		}
+		expression.Cases = append(expression.Cases, selectCase)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return expression
+}
+
+func (p *Parser) parseSelectCase() *ast.SelectCase {
+	selectCase := &ast.SelectCase{Token: p.currToken}
+
+	if !p.currTokenIs(token.IDENTIFIERS) || p.currToken.Literal != "_" {
+		// The operation stops before '=>' so that the arrow is not mistaken for an arrow function
+		errors := len(p.errors)
+		operation := p.parseExpression(LAMBDA)
+
+		// A malformed operation has already been reported, and may be only partly built
+		if len(p.errors) > errors {
+			return nil
+		}
+
+		call, ok := operation.(*ast.CallExpression)
+		if !ok || !isSelectOperation(call) {
+			msg := fmt.Sprintf("Invalid Select Case! Expected a call to send or receive but received '%s'", describe(operation))
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		selectCase.Operation = call
+
+		if p.peekTokenIs(token.AS) {
+			if call.Function.String() != "receive" {
+				msg := fmt.Sprintf("Invalid Select Case! Only a receive can bind a value, received '%s'", call.String())
+				p.errors = append(p.errors, msg)
+				return nil
+			}
+
+			p.nextToken()
+
+			if !p.expectPeek(token.IDENTIFIERS) {
+				return nil
+			}
+			selectCase.Binding = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+		}
+	}
+
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		selectCase.Body = p.parseBlockStatement()
+		return selectCase
+	}
+
+	p.nextToken()
+
+	body := &ast.ExpressionStatement{Token: p.currToken, Expression: p.parseExpression(LOWEST)}
+	selectCase.Body = &ast.BlockStatement{Token: body.Token, Statements: []ast.Statement{body}}
+
+	return selectCase
+}
+
+// This helper function reports whether a call is one of the channel operations a select case can wait on
+func isSelectOperation(call *ast.CallExpression) bool {
+	function, ok := call.Function.(*ast.Identifier)
+	return ok && (function.Value == "send" || function.Value == "receive")
+}
+
+// This helper function describes a parsed expression for an error message, even if parsing it failed
+func describe(expression ast.Expression) string {
+	if expression == nil {
+		return "nothing"
+	}
+	return expression.String()
+}
@@ -396,6 +396,105 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a + b + c * d / f + g)",
 			"add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a > b ? a : b",
+			"((a > b) ? a : b)",
+		},
+		{
+			"a ? b : c ? d : e",
+			"(a ? b : (c ? d : e))",
+		},
+		{
+			"a ? b ? c : d : e",
+			"(a ? (b ? c : d) : e)",
+		},
+		{
+			"a ?? b ?? c",
+			"(a ?? (b ?? c))",
+		},
+		{
+			"a ?? b == c",
+			"(a ?? (b == c))",
+		},
+		{
+			"a ?? b ? c : d",
+			"((a ?? b) ? c : d)",
+		},
+		{
+			"a + null",
+			"(a + null)",
+		},
+		{
+			"x |> a |> b(2) |> c(3)",
+			"c(b(a(x), 2), 3)",
+		},
+		{
+			"x + 1 |> f",
+			"f((x + 1))",
+		},
+		{
+			"x |> f ?? g",
+			"(f ?? g)(x)",
+		},
+		{
+			"f >> g >> h",
+			"((f >> g) >> h)",
+		},
+		{
+			"x |> f >> g",
+			"(f >> g)(x)",
+		},
+		{
+			"-a.b * c.d.e",
+			"((-a.b) * c.d.e)",
+		},
+		{
+			"a.b(c).d",
+			"a.b(c).d",
+		},
+		{
+			"a = b = c + 1",
+			"a = b = (c + 1)",
+		},
+		{
+			"a.b += x => x",
+			"a.b += fn(x) x",
+		},
+		{
+			"a = b ? c : d",
+			"a = (b ? c : d)",
+		},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", tt.expected, actual)
+		}
+	}
+}
+
+func TestOptionalSemicolons(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = 1\nlet b = 2\na + b", "let a = 1;let b = 2;(a + b)"},
+		{"let a = 1 +\n\t2 *\n\t3", "let a = (1 + (2 * 3));"},
+		{"add(\n\t1,\n\t2\n)", "add(1, 2)"},
+		{"let a = (1\n\t+ 2)\na", "let a = (1 + 2);a"},
+		{"a\n-b", "a(-b)"},
+		{"a\n(b)", "ab"},
+		{"let f = fn(x) {\n\tlet y = x * 2\n\ty\n}\nf(1)", "let f = fn(x) let y = (x * 2);y;f(1)"},
+		{"map(xs, x => {\n\tlet y = x\n\ty\n})", "map(xs, fn(x) let y = x;y)"},
+		{"if (a) {\n\tb\n} else {\n\tc\n}\nd", "ifa b else cd"},
+		{"return\n", "return ;"},
+		{"let a = 1;\n\nlet b = 2;;", "let a = 1;let b = 2;"},
 	}
 
 	for _, tt := range tests {
@@ -689,6 +788,309 @@ func TestFunctionParameterParsing(t *testing.T) {
 	}
 }
 
+func TestArrowFunctionParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+		expectedBody   string
+	}{
+		{"x => x * 2", []string{"x"}, "(x * 2)"},
+		{"(x) => x * 2", []string{"x"}, "(x * 2)"},
+		{"(a, b) => a + b", []string{"a", "b"}, "(a + b)"},
+		{"() => 1", []string{}, "1"},
+		{"x => { let y = x; y }", []string{"x"}, "let y = x;y"},
+		{"x => y => x + y", []string{"x"}, "fn(y) (x + y)"},
+		{"x => x |> f", []string{"x"}, "f(x)"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("Program does not have enough statements! Expected 1 but got '%d'", len(program.Statements))
+		}
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		function, ok := statement.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("Expression is not of type *ast.FunctionLiteral! Instead received '%T'", statement.Expression)
+		}
+
+		if len(function.Parameters) != len(tt.expectedParams) {
+			t.Fatalf("Incorrect amount of function literal parameters found! Expected %d but receieved '%d'", len(tt.expectedParams), len(function.Parameters))
+		}
+
+		for i, identifier := range tt.expectedParams {
+			testLiteralExpression(t, function.Parameters[i], identifier)
+		}
+
+		if function.Body.String() != tt.expectedBody {
+			t.Errorf("Function has an incorrect body! Expected %q but instead received %q", tt.expectedBody, function.Body.String())
+		}
+	}
+}
+
+func TestArrowFunctionInContext(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"map(xs, x => x * 2)", "map(xs, fn(x) (x * 2))"},
+		{"let add = (a, b) => a + b;", "let add = fn(a, b) (a + b);"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"match (x) { n if n > limit => n }", "match (x) { n if (n > limit) => n }"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", tt.expected, actual)
+		}
+	}
+}
+
+func TestStructParsing(t *testing.T) {
+	input := `struct Point { x, y }
+	let p = Point {
+		x: 1,
+		y: 2 * 3,
+	}
+	let q = Point { y: 4, ..p }
+	p.x + q.y`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 4 {
+		t.Fatalf("Program does not have enough statements! Expected 4 but got '%d'", len(program.Statements))
+	}
+
+	declaration, ok := program.Statements[0].(*ast.StructStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[0] is not of type ast.StructStatement! Instead received '%T'", program.Statements[0])
+	}
+
+	if declaration.Name.Value != "Point" || len(declaration.Fields) != 2 {
+		t.Fatalf("Struct declaration is incorrect! Instead received %q", declaration.String())
+	}
+
+	testIdentifier(t, declaration.Fields[0], "x")
+	testIdentifier(t, declaration.Fields[1], "y")
+
+	literal, ok := program.Statements[1].(*ast.LetStatement).Value.(*ast.StructLiteral)
+	if !ok {
+		t.Fatalf("Let value is not of type *ast.StructLiteral! Instead received '%T'", program.Statements[1].(*ast.LetStatement).Value)
+	}
+
+	if len(literal.Fields) != 2 || literal.Base != nil {
+		t.Fatalf("Struct literal is incorrect! Instead received %q", literal.String())
+	}
+
+	testIntegerLiteral(t, literal.Fields[0].Value, 1)
+	testInfixExpression(t, literal.Fields[1].Value, 2, "*", 3)
+
+	expected := "struct Point { x, y }let p = Point {x: 1, y: (2 * 3)};let q = Point {y: 4, ..p};(p.x + q.y)"
+	if program.String() != expected {
+		t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", expected, program.String())
+	}
+
+	statement := program.Statements[3].(*ast.ExpressionStatement)
+	infix := statement.Expression.(*ast.InfixExpression)
+
+	selector, ok := infix.Left.(*ast.SelectorExpression)
+	if !ok {
+		t.Fatalf("Expression is not of type *ast.SelectorExpression! Instead received '%T'", infix.Left)
+	}
+
+	testIdentifier(t, selector.Left, "p")
+	testIdentifier(t, selector.Field, "x")
+}
+
+func TestClassParsing(t *testing.T) {
+	input := `class Counter(Base) {
+		init(self) { self.n = 0 }
+
+		inc(self, by) {
+			self.n += by
+		}
+	}`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Program does not have enough statements! Expected 1 but got '%d'", len(program.Statements))
+	}
+
+	class, ok := program.Statements[0].(*ast.ClassStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[0] is not of type ast.ClassStatement! Instead received '%T'", program.Statements[0])
+	}
+
+	testIdentifier(t, class.Name, "Counter")
+	testIdentifier(t, class.Parent, "Base")
+
+	if len(class.Methods) != 2 {
+		t.Fatalf("Incorrect amount of class methods found! Expected 2 but receieved '%d'", len(class.Methods))
+	}
+
+	testIdentifier(t, class.Methods[1].Name, "inc")
+	testLiteralExpression(t, class.Methods[1].Function.Parameters[0], "self")
+	testLiteralExpression(t, class.Methods[1].Function.Parameters[1], "by")
+
+	expected := "class Counter(Base) { init(self) { self.n = 0 } inc(self, by) { self.n += by } }"
+	if program.String() != expected {
+		t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", expected, program.String())
+	}
+}
+
+func TestModuleParsing(t *testing.T) {
+	input := `import "lib/math" as m
+import "util"
+export let (lo, hi) = m.bounds()
+export struct Point { x, y }`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 4 {
+		t.Fatalf("Program does not have enough statements! Expected 4 but got '%d'", len(program.Statements))
+	}
+
+	imp, ok := program.Statements[0].(*ast.ImportStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[0] is not of type ast.ImportStatement! Instead received '%T'", program.Statements[0])
+	}
+
+	if imp.Path != "lib/math" {
+		t.Errorf("Import path is incorrect! Expected 'lib/math' but instead received '%s'", imp.Path)
+	}
+	testIdentifier(t, imp.Alias, "m")
+
+	if alias := program.Statements[1].(*ast.ImportStatement).Alias; alias != nil {
+		t.Errorf("Import without 'as' should have no alias! Instead received '%s'", alias)
+	}
+
+	export, ok := program.Statements[2].(*ast.ExportStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[2] is not of type ast.ExportStatement! Instead received '%T'", program.Statements[2])
+	}
+
+	if _, ok := export.Statement.(*ast.LetStatement); !ok {
+		t.Errorf("Exported statement is not of type ast.LetStatement! Instead received '%T'", export.Statement)
+	}
+
+	expected := `import "lib/math" as m;import "util";export let (lo, hi) = m.bounds();export struct Point { x, y }`
+	if program.String() != expected {
+		t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", expected, program.String())
+	}
+}
+
+func TestConcurrencyParsing(t *testing.T) {
+	input := `let task = spawn work(1, 2)
+select {
+	receive(ch) as v => v,
+	send(out, 1) => { 2 },
+	_ => 3
+}`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("Program does not have enough statements! Expected 2 but got '%d'", len(program.Statements))
+	}
+
+	spawn, ok := program.Statements[0].(*ast.LetStatement).Value.(*ast.SpawnExpression)
+	if !ok {
+		t.Fatalf("Let value is not of type ast.SpawnExpression! Instead received '%T'", program.Statements[0].(*ast.LetStatement).Value)
+	}
+
+	if _, ok := spawn.Call.(*ast.CallExpression); !ok {
+		t.Errorf("Spawned expression is not of type ast.CallExpression! Instead received '%T'", spawn.Call)
+	}
+
+	sel, ok := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.SelectExpression)
+	if !ok {
+		t.Fatalf("Expression is not of type ast.SelectExpression! Instead received '%T'", program.Statements[1].(*ast.ExpressionStatement).Expression)
+	}
+
+	if len(sel.Cases) != 3 {
+		t.Fatalf("Select does not have enough cases! Expected 3 but got '%d'", len(sel.Cases))
+	}
+
+	testIdentifier(t, sel.Cases[0].Binding, "v")
+
+	if sel.Cases[2].Operation != nil {
+		t.Errorf("Default case should have no operation! Instead received '%s'", sel.Cases[2].Operation)
+	}
+
+	expected := `let task = spawn work(1, 2);select { receive(ch) as v => v, send(out, 1) => 2, _ => 3 }`
+	if program.String() != expected {
+		t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", expected, program.String())
+	}
+}
+
+func TestGeneratorParsing(t *testing.T) {
+	tests := []struct {
+		input     string
+		generator bool
+		expected  string
+	}{
+		{`fn() { yield 1; yield }`, true, "fn() yield 1yield"},
+		{`fn(x) { let y = yield x * 2; y }`, true, "fn(x) let y = yield (x * 2);y"},
+		{`x => yield x`, true, "fn(x) yield x"},
+		{`fn() { fn() { yield 1 } }`, false, "fn() fn() yield 1"},
+		{`fn() { 1 }`, false, "fn() 1"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := statement.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("Expression is not of type ast.FunctionLiteral! Instead received '%T'", statement.Expression)
+		}
+
+		if literal.Generator != tt.generator {
+			t.Errorf("Incorrect generator flag for %q! Expected %t but instead received %t", tt.input, tt.generator, literal.Generator)
+		}
+
+		if literal.String() != tt.expected {
+			t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", tt.expected, literal.String())
+		}
+	}
+
+	input := `class Range { each(self) { yield 1 } }`
+	prsr := New(lexer.New(input))
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if method := program.Statements[0].(*ast.ClassStatement).Methods[0]; !method.Function.Generator {
+		t.Errorf("Class method containing a yield was not parsed as a generator")
+	}
+}
+
 func TestCallExpressionParsing(t *testing.T) {
 	input := "add(1, 2 * 3, 4 + 5);"
 
@@ -746,3 +1148,226 @@ func TestStringLiteralExpression(t *testing.T) {
 		t.Errorf("String Literal Value is not 'hello world'. Instead received '%q'", strLiteral.Value)
 	}
 }
+
+func TestElseIfExpression(t *testing.T) {
+	input := `if (x < y) { x } else if (x > y) { y } else { z }`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Program does not have enough statements! Expected 1 but got '%d'", len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[0] is not of type ast.ExpressionStatement! Instead received '%T'", program.Statements[0])
+	}
+
+	exp, ok := statement.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("Expression is not of type *ast.IfExpression! Instead received '%T'", statement.Expression)
+	}
+
+	if len(exp.Alternative.Statements) != 1 {
+		t.Fatalf("Incorrect amount of Alternatives detected! Needed 1 but received '%d'", len(exp.Alternative.Statements))
+	}
+
+	alternative, ok := exp.Alternative.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Statement[0] is not of type ast.ExpressionStatement! Instead received '%T'", exp.Alternative.Statements[0])
+	}
+
+	nested, ok := alternative.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("Alternative is not of type *ast.IfExpression! Instead received '%T'", alternative.Expression)
+	}
+
+	if !testInfixExpression(t, nested.Condition, "x", ">", "y") {
+		return
+	}
+
+	if nested.Alternative == nil || len(nested.Alternative.Statements) != 1 {
+		t.Fatalf("Nested if expression is missing its else block! Instead received '%v'", nested.Alternative)
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	input := `match (x) { 1 => "one", "x" | "y" => "letter", n if n > 10 => n, -1 => "negative", _ => { "other" } }`
+
+	lxr := lexer.New(input)
+	prsr := New(lxr)
+	program := prsr.ParseProgram()
+	checkForParseErrors(t, prsr)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Program does not have enough statements! Expected 1 but got '%d'", len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Program.Statement[0] is not of type ast.ExpressionStatement! Instead received '%T'", program.Statements[0])
+	}
+
+	exp, ok := statement.Expression.(*ast.MatchExpression)
+	if !ok {
+		t.Fatalf("Expression is not of type *ast.MatchExpression! Instead received '%T'", statement.Expression)
+	}
+
+	if !testIdentifier(t, exp.Subject, "x") {
+		return
+	}
+
+	if len(exp.Arms) != 5 {
+		t.Fatalf("Incorrect amount of Match Arms detected! Needed 5 but received '%d'", len(exp.Arms))
+	}
+
+	expectedArms := []string{
+		"1 => one",
+		"x | y => letter",
+		"n if (n > 10) => n",
+		"(-1) => negative",
+		"_ => other",
+	}
+
+	for i, expected := range expectedArms {
+		if exp.Arms[i].String() != expected {
+			t.Errorf("Match Arm %d is incorrect! Expected %q but instead received %q", i, expected, exp.Arms[i].String())
+		}
+	}
+
+	if exp.Arms[2].Guard == nil {
+		t.Fatalf("Match Arm 2 is missing its guard!")
+	}
+}
+
+func TestParsingErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`match (x) { x + 1 => 2 }`, "Expected next token to be '=>', instead received '+'!"},
+		{`match (x) { fn => 2 }`, "Invalid Pattern! Received 'fn'"},
+		{`let (a, b + 1) = pair;`, "Expected next token to be ')', instead received '+'!"},
+		{`(a, 1) => a`, "Invalid Arrow Function Parameter! Received '1'"},
+		{`(a + b) => a`, "Invalid Arrow Function Parameter! Received '(a + b)'"},
+		{`()`, "Expected next token to be '=>', instead received 'EOF'!"},
+		{`struct Point { x, 1 }`, "Expected next token to be 'IDENTIFIERS', instead received 'INT'!"},
+		{`Point { x: 1, y 2 }`, "Expected next token to be ':', instead received 'INT'!"},
+		{`Point { x }`, "Expected end of statement, instead received '{'!"},
+		{`f() { x: 1 }`, "Expected end of statement, instead received '{'!"},
+		{`a + b = c`, "Invalid Assignment Target! Received '(a + b)'"},
+		{`class A { 1 }`, "Expected next token to be 'IDENTIFIERS', instead received 'INT'!"},
+		{`let a = 1 let b = 2`, "Expected end of statement, instead received 'LET'!"},
+		{`a + b c`, "Expected end of statement, instead received 'IDENTIFIERS'!"},
+		{"if (a) {\n\tb\n}\nelse {\n\tc\n}", "No Prefix Parse function found for ELSE found!"},
+		{`match (x) { 1 => 2 3 => 4 }`, "Expected next token to be ',', instead received 'INT'!"},
+		{`import math`, "Expected next token to be 'STRING', instead received 'IDENTIFIERS'!"},
+		{`import "math" as`, "Expected next token to be 'IDENTIFIERS', instead received 'EOF'!"},
+		{`export 1 + 2`, "Only let, struct and class declarations can be exported! Received '1'"},
+		{`select { close(ch) => 1 }`, "Invalid Select Case! Expected a call to send or receive but received 'close(ch)'"},
+		{`select { ch => 1 }`, "Invalid Select Case! Expected a call to send or receive but received 'ch'"},
+		{`select { send(ch, 1) as v => v }`, "Invalid Select Case! Only a receive can bind a value, received 'send(ch, 1)'"},
+		{`select { - }`, "No Prefix Parse function found for } found!"},
+		{`select { receive(ch) + }`, "No Prefix Parse function found for } found!"},
+		{`select { send(c, 1 +) as v => v }`, "No Prefix Parse function found for ) found!"},
+		{`yield 1`, "Invalid Yield! yield can only be used inside a function"},
+		{`let f = fn() { 1 }; yield`, "Invalid Yield! yield can only be used inside a function"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		prsr.ParseProgram()
+
+		errors := prsr.Errors()
+		if len(errors) == 0 {
+			t.Errorf("Parser did not report an error for %q", tt.input)
+			continue
+		}
+
+		if errors[0] != tt.expectedError {
+			t.Errorf("Parser reported the incorrect error! Expected %q but instead received %q", tt.expectedError, errors[0])
+		}
+	}
+}
+
+func TestTupleLiteralParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"(1, 2)", "(1, 2)"},
+		{"(1,)", "(1)"},
+		{"(a + b, (c, d), f(e))", "((a + b), (c, d), f(e))"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", tt.expected, actual)
+		}
+	}
+
+	program := New(lexer.New("(1, 2)")).ParseProgram()
+	statement := program.Statements[0].(*ast.ExpressionStatement)
+	tuple, ok := statement.Expression.(*ast.TupleLiteral)
+	if !ok {
+		t.Fatalf("Expression is not of type *ast.TupleLiteral! Instead received '%T'", statement.Expression)
+	}
+
+	testIntegerLiteral(t, tuple.Elements[0], 1)
+	testIntegerLiteral(t, tuple.Elements[1], 2)
+}
+
+func TestPatternParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let (a, b) = pair;", "let (a, b) = pair;"},
+		{"let (a, (b, _)) = pair;", "let (a, (b, _)) = pair;"},
+		{"match (p) { (0, y) => y, ((1 | 2), _) => 1, (x, -1) if x > 0 => x }", "match (p) { (0, y) => y, (1 | 2, _) => 1, (x, (-1)) if (x > 0) => x }"},
+	}
+
+	for _, tt := range tests {
+		lxr := lexer.New(tt.input)
+		prsr := New(lxr)
+		program := prsr.ParseProgram()
+		checkForParseErrors(t, prsr)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("Incorrect parsing detected!. Expected %q but instead received '%q'", tt.expected, actual)
+		}
+	}
+
+	program := New(lexer.New("let (a, (b, _)) = pair;")).ParseProgram()
+	statement := program.Statements[0].(*ast.LetStatement)
+
+	tuple, ok := statement.Pattern.(*ast.TuplePattern)
+	if !ok {
+		t.Fatalf("Pattern is not of type *ast.TuplePattern! Instead received '%T'", statement.Pattern)
+	}
+
+	if _, ok := tuple.Elements[0].(*ast.IdentifierPattern); !ok {
+		t.Errorf("Pattern is not of type *ast.IdentifierPattern! Instead received '%T'", tuple.Elements[0])
+	}
+
+	nested, ok := tuple.Elements[1].(*ast.TuplePattern)
+	if !ok {
+		t.Fatalf("Pattern is not of type *ast.TuplePattern! Instead received '%T'", tuple.Elements[1])
+	}
+
+	if _, ok := nested.Elements[1].(*ast.WildcardPattern); !ok {
+		t.Errorf("Pattern is not of type *ast.WildcardPattern! Instead received '%T'", nested.Elements[1])
+	}
+}
@@ -16,6 +16,9 @@ const PROMPT = ">>"
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
+	eval := evaluator.New()
+	eval.Capabilities = evaluator.AllCapabilities()
+	eval.Output = out
 
 	for {
 		fmt.Fprintf(out, PROMPT)
@@ -34,7 +37,7 @@ func Start(in io.Reader, out io.Writer) {
 			continue
 		}
 
-		evaluated := evaluator.Evaluate(program, env)
+		evaluated := eval.Evaluate(program, env)
 		if evaluated != nil {
 			io.WriteString(out, evaluated.Inspect())
 			io.WriteString(out, "\n")
@@ -0,0 +1,179 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/armansandhu/monkey_interpreter/ast"
+)
+
+// the node types which can appear where the AST holds a Statement, Expression or Pattern, keyed by name
+var nodeTypes = make(map[string]reflect.Type)
+
+func init() {
+	nodes := []ast.Node{
+		&ast.LetStatement{}, &ast.ReturnStatement{}, &ast.ExpressionStatement{}, &ast.BlockStatement{},
+		&ast.StructStatement{}, &ast.ClassStatement{}, &ast.ImportStatement{}, &ast.ExportStatement{},
+		&ast.Identifier{}, &ast.IntegerLiteral{}, &ast.StringLiteral{}, &ast.Boolean{}, &ast.NullLiteral{},
+		&ast.PrefixExpression{}, &ast.InfixExpression{}, &ast.ConditionalExpression{}, &ast.IfExpression{},
+		&ast.FunctionLiteral{}, &ast.CallExpression{}, &ast.MatchExpression{}, &ast.StructLiteral{},
+		&ast.SelectorExpression{}, &ast.AssignExpression{}, &ast.TupleLiteral{},
+		&ast.SpawnExpression{}, &ast.SelectExpression{}, &ast.YieldExpression{},
+		&ast.WildcardPattern{}, &ast.IdentifierPattern{}, &ast.LiteralPattern{}, &ast.TuplePattern{}, &ast.AlternativePattern{},
+	}
+
+	for _, node := range nodes {
+		nodeType := reflect.TypeOf(node).Elem()
+		nodeTypes[nodeType.Name()] = nodeType
+	}
+}
+
+// This function converts an AST node into a value which encoding/json can write. Every struct pointer is written as
+// an object holding its exported fields, along with its type name under "node" so that interface fields can be restored.
+func encodeNode(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeNode(v.Elem())
+	case reflect.Struct:
+		fields := map[string]interface{}{"node": v.Type().Name()}
+		for index := 0; index < v.NumField(); index++ {
+			if !v.Type().Field(index).IsExported() {
+				continue
+			}
+			value, err := encodeNode(v.Field(index))
+			if err != nil {
+				return nil, err
+			}
+			fields[v.Type().Field(index).Name] = value
+		}
+		return fields, nil
+	case reflect.Slice:
+		elements := make([]interface{}, v.Len())
+		for index := range elements {
+			element, err := encodeNode(v.Index(index))
+			if err != nil {
+				return nil, err
+			}
+			elements[index] = element
+		}
+		return elements, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int64:
+		return v.Int(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	default:
+		return nil, fmt.Errorf("snapshot: cannot encode %s in a syntax tree", v.Type())
+	}
+}
+
+// This function rebuilds a value of type t from data written by encodeNode and read back by a decoder using UseNumber
+func decodeNode(data interface{}, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if data == nil {
+			return reflect.Zero(t), nil
+		}
+		node := reflect.New(t.Elem())
+		if err := decodeFields(data, node.Elem()); err != nil {
+			return reflect.Value{}, err
+		}
+		return node, nil
+	case reflect.Interface:
+		if data == nil {
+			return reflect.Zero(t), nil
+		}
+		fields, ok := data.(map[string]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: expected a syntax tree node but found %v", data)
+		}
+		name, _ := fields["node"].(string)
+		nodeType, ok := nodeTypes[name]
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: unknown syntax tree node %q", name)
+		}
+		if !reflect.PtrTo(nodeType).Implements(t) {
+			return reflect.Value{}, fmt.Errorf("snapshot: %s cannot be used as %s", name, t)
+		}
+		return decodeNode(data, reflect.PtrTo(nodeType))
+	case reflect.Struct:
+		value := reflect.New(t).Elem()
+		if err := decodeFields(data, value); err != nil {
+			return reflect.Value{}, err
+		}
+		return value, nil
+	case reflect.Slice:
+		if data == nil {
+			return reflect.Zero(t), nil
+		}
+		elements, ok := data.([]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: expected a list but found %v", data)
+		}
+		slice := reflect.MakeSlice(t, len(elements), len(elements))
+		for index, element := range elements {
+			value, err := decodeNode(element, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(index).Set(value)
+		}
+		return slice, nil
+	case reflect.String:
+		str, ok := data.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: expected a string but found %v", data)
+		}
+		return reflect.ValueOf(str).Convert(t), nil
+	case reflect.Int64:
+		number, ok := data.(json.Number)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: expected an integer but found %v", data)
+		}
+		integer, err := number.Int64()
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("snapshot: %w", err)
+		}
+		return reflect.ValueOf(integer), nil
+	case reflect.Bool:
+		boolean, ok := data.(bool)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: expected a boolean but found %v", data)
+		}
+		return reflect.ValueOf(boolean), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("snapshot: cannot decode %s in a syntax tree", t)
+	}
+}
+
+// This helper function fills the exported fields of a struct from the object written for it by encodeNode
+func decodeFields(data interface{}, value reflect.Value) error {
+	fields, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("snapshot: expected %s but found %v", value.Type().Name(), data)
+	}
+
+	if name, ok := fields["node"].(string); !ok || name != value.Type().Name() {
+		return fmt.Errorf("snapshot: expected %s but found %v", value.Type().Name(), fields["node"])
+	}
+
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		decoded, err := decodeNode(fields[field.Name], field.Type)
+		if err != nil {
+			return err
+		}
+		value.Field(index).Set(decoded)
+	}
+
+	return nil
+}
@@ -0,0 +1,577 @@
+// Package snapshot saves an environment, along with every value and closure reachable from it, and restores it later.
+//
+// A snapshot is a versioned JSON document. Environments and values are stored once each in tables and refer to one
+// another by index, so closures which shared an environment before saving share it again after restoring, and
+// recursive functions and other cycles survive the round trip. Function bodies are stored as syntax trees.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/armansandhu/monkey_interpreter/ast"
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/token"
+)
+
+// Version is the version of the format written by Save. Load refuses snapshots with any other version.
+const Version = 1
+
+// the reference used for a missing environment or value
+const none = -1
+
+type document struct {
+	Version      int                 `json:"version"`
+	Root         int                 `json:"root"`
+	Environments []environmentRecord `json:"environments"`
+	Objects      []objectRecord      `json:"objects"`
+	Bodies       []interface{}       `json:"bodies"`
+}
+
+type environmentRecord struct {
+	Outer    int           `json:"outer"`
+	Frozen   bool          `json:"frozen,omitempty"`
+	Bindings []fieldRecord `json:"bindings"`
+}
+
+type fieldRecord struct {
+	Name   string `json:"name"`
+	Object int    `json:"object"`
+}
+
+// Each value is stored as one record. Type is the value's object.ObjectType, and which of the other fields are used depends on it.
+type objectRecord struct {
+	Type       object.ObjectType `json:"type"`
+	Integer    int64             `json:"integer,omitempty"`
+	Boolean    bool              `json:"boolean,omitempty"`
+	String     string            `json:"string,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Kind       object.ErrorKind  `json:"kind,omitempty"`
+	Parameters []string          `json:"parameters,omitempty"`
+	FieldNames []string          `json:"fieldNames,omitempty"`
+	Elements   []int             `json:"elements,omitempty"`
+	Fields     []fieldRecord     `json:"fields,omitempty"`
+	Body       int               `json:"body,omitempty"`
+	Env        int               `json:"env,omitempty"`
+	Refs       []int             `json:"refs,omitempty"`
+}
+
+// This function writes env, the environments enclosing it and every value reachable from them to w
+func Save(w io.Writer, env *object.Environment) error {
+	s := &saver{
+		environments: make(map[*object.Environment]int),
+		objects:      make(map[object.Object]int),
+		bodies:       make(map[*ast.BlockStatement]int),
+	}
+
+	root, err := s.environment(env)
+	if err != nil {
+		return err
+	}
+
+	s.doc.Version = Version
+	s.doc.Root = root
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.doc)
+}
+
+// This function reads a snapshot written by Save and returns the environment it was taken of.
+// Bound builtin methods are looked up in the default builtins.
+func Load(r io.Reader) (*object.Environment, error) {
+	return LoadWith(r, evaluator.DefaultBuiltins())
+}
+
+// This function reads a snapshot like Load, looking up bound builtin methods in builtins, such as the registry of the
+// Evaluator which will run the restored environment
+func LoadWith(r io.Reader, builtins *evaluator.Builtins) (*object.Environment, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var doc document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	if doc.Version != Version {
+		return nil, fmt.Errorf("snapshot: unsupported version %d, expected %d", doc.Version, Version)
+	}
+
+	l := &loader{
+		doc:          &doc,
+		environments: make([]*object.Environment, len(doc.Environments)),
+		objects:      make([]object.Object, len(doc.Objects)),
+		bodies:       make([]*ast.BlockStatement, len(doc.Bodies)),
+		builtins:     builtins,
+	}
+
+	return l.load()
+}
+
+type saver struct {
+	doc          document
+	environments map[*object.Environment]int
+	objects      map[object.Object]int
+	bodies       map[*ast.BlockStatement]int
+}
+
+// This method adds an environment to the document if it is not there already, returning its index
+func (s *saver) environment(env *object.Environment) (int, error) {
+	if env == nil {
+		return none, nil
+	}
+
+	if index, ok := s.environments[env]; ok {
+		return index, nil
+	}
+
+	index := len(s.doc.Environments)
+	s.environments[env] = index
+	s.doc.Environments = append(s.doc.Environments, environmentRecord{Frozen: env.Frozen()})
+
+	outer, err := s.environment(env.Outer())
+	if err != nil {
+		return none, err
+	}
+
+	bindings := []fieldRecord{}
+	for _, name := range env.Names() {
+		value, _ := env.Get(name)
+		ref, err := s.object(value)
+		if err != nil {
+			return none, fmt.Errorf("%w (bound to %s)", err, name)
+		}
+		bindings = append(bindings, fieldRecord{Name: name, Object: ref})
+	}
+
+	s.doc.Environments[index].Outer = outer
+	s.doc.Environments[index].Bindings = bindings
+	return index, nil
+}
+
+// This method adds a value to the document if it is not there already, returning its index
+func (s *saver) object(obj object.Object) (int, error) {
+	if obj == nil {
+		return none, nil
+	}
+
+	if index, ok := s.objects[obj]; ok {
+		return index, nil
+	}
+
+	// The record is added before the values it refers to, so that cycles back to it find its index
+	index := len(s.doc.Objects)
+	s.objects[obj] = index
+	s.doc.Objects = append(s.doc.Objects, objectRecord{Type: obj.Type()})
+	record := objectRecord{Type: obj.Type()}
+
+	var err error
+	switch obj := obj.(type) {
+	case *object.Integer:
+		record.Integer = obj.Value
+	case *object.Boolean:
+		record.Boolean = obj.Value
+	case *object.Null:
+	case *object.String:
+		record.String = obj.Value
+	case *object.Error:
+		record.String = obj.Message
+		record.Kind = obj.Kind
+	case *object.Tuple:
+		record.Elements, err = s.objectList(obj.Elements...)
+	case *object.Function:
+		record.Boolean = obj.Generator
+		for _, parameter := range obj.Parameters {
+			record.Parameters = append(record.Parameters, parameter.Value)
+		}
+		if record.Body, err = s.body(obj.Body); err == nil {
+			record.Env, err = s.environment(obj.Env)
+		}
+	case *object.Composition:
+		record.Refs, err = s.objectList(obj.First, obj.Second)
+	case *object.StructDefinition:
+		record.Name = obj.Name
+		record.FieldNames = obj.Fields
+	case *object.Struct:
+		if record.Refs, err = s.objectList(obj.Definition); err == nil {
+			record.Fields, err = s.fieldList(obj.Definition.Fields, obj.Fields)
+		}
+	case *object.Class:
+		record.Name = obj.Name
+		methods := make(map[string]object.Object, len(obj.Methods))
+		names := make([]string, 0, len(obj.Methods))
+		for name, method := range obj.Methods {
+			methods[name] = method
+			names = append(names, name)
+		}
+		if record.Refs, err = s.objectList(classOrNil(obj.Parent)); err == nil {
+			record.Fields, err = s.fieldList(sortedNames(names), methods)
+		}
+	case *object.Instance:
+		if record.Refs, err = s.objectList(obj.Class); err == nil {
+			fields := make(map[string]object.Object)
+			for _, name := range obj.FieldNames() {
+				fields[name], _ = obj.GetField(name)
+			}
+			record.Fields, err = s.fieldList(obj.FieldNames(), fields)
+		}
+	case *object.BoundMethod:
+		record.Name = obj.Name
+		// Methods of built-in types are looked up again by name when the snapshot is loaded
+		method := obj.Method
+		if _, ok := method.(*object.BuiltIn); ok && obj.Class == nil {
+			method = nil
+		}
+		record.Refs, err = s.objectList(obj.Receiver, method, classOrNil(obj.Class))
+	case *object.Super:
+		record.Refs, err = s.objectList(obj.Class, obj.Receiver)
+	case *object.Module:
+		record.Name = obj.Name
+		record.String = obj.Path
+		names := make([]string, 0, len(obj.Exports))
+		for name := range obj.Exports {
+			names = append(names, name)
+		}
+		record.Fields, err = s.fieldList(sortedNames(names), obj.Exports)
+	default:
+		err = fmt.Errorf("snapshot: cannot save a value of type %s", obj.Type())
+	}
+
+	if err != nil {
+		return none, err
+	}
+
+	s.doc.Objects[index] = record
+	return index, nil
+}
+
+// This helper method adds several values to the document, returning their indexes
+func (s *saver) objectList(objects ...object.Object) ([]int, error) {
+	refs := make([]int, len(objects))
+	for index, obj := range objects {
+		ref, err := s.object(obj)
+		if err != nil {
+			return nil, err
+		}
+		refs[index] = ref
+	}
+	return refs, nil
+}
+
+// This helper method adds the named values to the document in the order given
+func (s *saver) fieldList(names []string, values map[string]object.Object) ([]fieldRecord, error) {
+	fields := make([]fieldRecord, 0, len(names))
+	for _, name := range names {
+		ref, err := s.object(values[name])
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, fieldRecord{Name: name, Object: ref})
+	}
+	return fields, nil
+}
+
+// This method adds a function body to the document if it is not there already, returning its index
+func (s *saver) body(body *ast.BlockStatement) (int, error) {
+	if index, ok := s.bodies[body]; ok {
+		return index, nil
+	}
+
+	encoded, err := encodeNode(reflect.ValueOf(body))
+	if err != nil {
+		return none, err
+	}
+
+	index := len(s.doc.Bodies)
+	s.bodies[body] = index
+	s.doc.Bodies = append(s.doc.Bodies, encoded)
+	return index, nil
+}
+
+type loader struct {
+	doc          *document
+	environments []*object.Environment
+	objects      []object.Object
+	bodies       []*ast.BlockStatement
+	builtins     *evaluator.Builtins
+}
+
+// This method rebuilds every environment and value in the document and returns the root environment.
+// Values are created empty first and filled in afterwards, so that references between them can form cycles.
+func (l *loader) load() (*object.Environment, error) {
+	for index, record := range l.doc.Objects {
+		obj, err := newObject(record)
+		if err != nil {
+			return nil, err
+		}
+		l.objects[index] = obj
+	}
+
+	for index := range l.doc.Environments {
+		if _, err := l.environment(index, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	for index, record := range l.doc.Objects {
+		if err := l.fill(l.objects[index], record); err != nil {
+			return nil, err
+		}
+	}
+
+	for index, record := range l.doc.Environments {
+		for _, binding := range record.Bindings {
+			value, err := l.value(binding.Object)
+			if err != nil {
+				return nil, err
+			}
+			l.environments[index].Set(binding.Name, value)
+		}
+	}
+
+	for index, record := range l.doc.Environments {
+		if record.Frozen {
+			l.environments[index].Freeze()
+		}
+	}
+
+	if l.doc.Root == none {
+		return nil, fmt.Errorf("snapshot: missing root environment")
+	}
+	return l.environment(l.doc.Root, 0)
+}
+
+// This method returns the environment at index, creating it and the environments it encloses if needed
+func (l *loader) environment(index int, depth int) (*object.Environment, error) {
+	if index == none {
+		return nil, nil
+	}
+
+	if index < 0 || index >= len(l.environments) || depth > len(l.environments) {
+		return nil, fmt.Errorf("snapshot: invalid environment %d", index)
+	}
+
+	if l.environments[index] == nil {
+		outer, err := l.environment(l.doc.Environments[index].Outer, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if outer == nil {
+			l.environments[index] = object.NewEnvironment()
+		} else {
+			l.environments[index] = object.NewEnclosedEnvironment(outer)
+		}
+	}
+
+	return l.environments[index], nil
+}
+
+// This method returns the value at index
+func (l *loader) object(index int) (object.Object, error) {
+	if index == none {
+		return nil, nil
+	}
+
+	if index < 0 || index >= len(l.objects) {
+		return nil, fmt.Errorf("snapshot: invalid value %d", index)
+	}
+
+	return l.objects[index], nil
+}
+
+// This method returns the value at index, which must not be missing
+func (l *loader) value(index int) (object.Object, error) {
+	if index == none {
+		return nil, fmt.Errorf("snapshot: missing value")
+	}
+	return l.object(index)
+}
+
+// This helper function creates the value for a record. Values which refer to others are left empty to be filled in later.
+func newObject(record objectRecord) (object.Object, error) {
+	switch record.Type {
+	case object.INTEGER_OBJ:
+		return &object.Integer{Value: record.Integer}, nil
+	case object.BOOLEAN_OBJ:
+		if record.Boolean {
+			return evaluator.TRUE, nil
+		}
+		return evaluator.FALSE, nil
+	case object.NULL_OBJ:
+		return evaluator.NULL, nil
+	case object.STRING_OBJ:
+		return &object.String{Value: record.String}, nil
+	case object.ERROR_OBJ:
+		return &object.Error{Message: record.String, Kind: record.Kind}, nil
+	case object.TUPLE_OBJ:
+		return &object.Tuple{}, nil
+	case object.FUNCTION_OBJ:
+		return &object.Function{}, nil
+	case object.COMPOSITION_OBJ:
+		return &object.Composition{}, nil
+	case object.STRUCT_DEF_OBJ:
+		return &object.StructDefinition{Name: record.Name, Fields: record.FieldNames}, nil
+	case object.STRUCT_OBJ:
+		return &object.Struct{Fields: make(map[string]object.Object)}, nil
+	case object.CLASS_OBJ:
+		return &object.Class{Name: record.Name, Methods: make(map[string]*object.Function)}, nil
+	case object.INSTANCE_OBJ:
+		return object.NewInstance(nil), nil
+	case object.BOUND_METHOD_OBJ:
+		return &object.BoundMethod{Name: record.Name}, nil
+	case object.SUPER_OBJ:
+		return &object.Super{}, nil
+	case object.MODULE_OBJ:
+		return &object.Module{Name: record.Name, Path: record.String, Exports: make(map[string]object.Object)}, nil
+	default:
+		return nil, fmt.Errorf("snapshot: cannot restore a value of type %s", record.Type)
+	}
+}
+
+// This method fills in the references of a value created by newObject
+func (l *loader) fill(obj object.Object, record objectRecord) error {
+	refs := make([]object.Object, len(record.Refs))
+	for index, ref := range record.Refs {
+		value, err := l.object(ref)
+		if err != nil {
+			return err
+		}
+		refs[index] = value
+	}
+
+	fields := make(map[string]object.Object, len(record.Fields))
+	for _, field := range record.Fields {
+		value, err := l.value(field.Object)
+		if err != nil {
+			return err
+		}
+		fields[field.Name] = value
+	}
+
+	var ok = true
+	switch obj := obj.(type) {
+	case *object.Tuple:
+		for _, ref := range record.Elements {
+			element, err := l.value(ref)
+			if err != nil {
+				return err
+			}
+			obj.Elements = append(obj.Elements, element)
+		}
+	case *object.Function:
+		for _, parameter := range record.Parameters {
+			obj.Parameters = append(obj.Parameters, &ast.Identifier{Token: identifierToken(parameter), Value: parameter})
+		}
+		body, err := l.body(record.Body)
+		if err != nil {
+			return err
+		}
+		env, err := l.environment(record.Env, 0)
+		if err != nil {
+			return err
+		}
+		obj.Body, obj.Env, obj.Generator = body, env, record.Boolean
+	case *object.Composition:
+		ok = len(refs) == 2 && refs[0] != nil && refs[1] != nil
+		if ok {
+			obj.First, obj.Second = refs[0], refs[1]
+		}
+	case *object.Struct:
+		obj.Definition, ok = single(refs).(*object.StructDefinition)
+		obj.Fields = fields
+	case *object.Class:
+		if parent := single(refs); parent != nil {
+			obj.Parent, ok = parent.(*object.Class)
+		}
+		for name, method := range fields {
+			function, isFunction := method.(*object.Function)
+			ok = ok && isFunction
+			obj.Methods[name] = function
+		}
+	case *object.Instance:
+		obj.Class, ok = single(refs).(*object.Class)
+		for _, field := range record.Fields {
+			obj.SetField(field.Name, fields[field.Name])
+		}
+	case *object.BoundMethod:
+		ok = len(refs) == 3 && refs[0] != nil
+		if ok {
+			obj.Receiver, obj.Method = refs[0], refs[1]
+			if refs[2] != nil {
+				obj.Class, ok = refs[2].(*object.Class)
+			}
+		}
+		if ok && obj.Method == nil {
+			method, found := l.builtins.LookupMethod(obj.Receiver.Type(), obj.Name)
+			if !found {
+				return fmt.Errorf("snapshot: %s has no method '%s'", obj.Receiver.Type(), obj.Name)
+			}
+			obj.Method = method
+		}
+	case *object.Super:
+		ok = len(refs) == 2
+		if ok {
+			if obj.Class, ok = refs[0].(*object.Class); ok {
+				obj.Receiver, ok = refs[1].(*object.Instance)
+			}
+		}
+	case *object.Module:
+		obj.Exports = fields
+	}
+
+	if !ok {
+		return fmt.Errorf("snapshot: invalid references in a value of type %s", record.Type)
+	}
+	return nil
+}
+
+// This method returns the function body at index, decoding it the first time it is needed so that closures created
+// from the same function literal share it
+func (l *loader) body(index int) (*ast.BlockStatement, error) {
+	if index < 0 || index >= len(l.bodies) {
+		return nil, fmt.Errorf("snapshot: invalid function body %d", index)
+	}
+
+	if l.bodies[index] == nil {
+		decoded, err := decodeNode(l.doc.Bodies[index], reflect.TypeOf(l.bodies[index]))
+		if err != nil {
+			return nil, err
+		}
+		l.bodies[index] = decoded.Interface().(*ast.BlockStatement)
+	}
+
+	return l.bodies[index], nil
+}
+
+// This helper function returns a class as a value, keeping a missing class nil rather than a nil *object.Class
+func classOrNil(class *object.Class) object.Object {
+	if class == nil {
+		return nil
+	}
+	return class
+}
+
+// This helper function sorts names so that snapshots of the same values are always written identically
+func sortedNames(names []string) []string {
+	sort.Strings(names)
+	return names
+}
+
+// This helper function returns the only reference of a value, or nil if it does not have exactly one
+func single(refs []object.Object) object.Object {
+	if len(refs) != 1 {
+		return nil
+	}
+	return refs[0]
+}
+
+// This helper function creates the token of a restored function parameter
+func identifierToken(name string) token.Token {
+	return token.Token{Type: token.IDENTIFIERS, Literal: name}
+}
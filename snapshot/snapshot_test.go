@@ -0,0 +1,209 @@
+package snapshot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/armansandhu/monkey_interpreter/evaluator"
+	"github.com/armansandhu/monkey_interpreter/lexer"
+	"github.com/armansandhu/monkey_interpreter/object"
+	"github.com/armansandhu/monkey_interpreter/parser"
+)
+
+// This helper function evaluates input in env, failing the test on errors
+func testEvaluate(t *testing.T, input string, env *object.Environment) object.Object {
+	t.Helper()
+
+	prsr := parser.New(lexer.New(input))
+	program := prsr.ParseProgram()
+	if len(prsr.Errors()) != 0 {
+		t.Fatalf("Parser has %d errors: %v", len(prsr.Errors()), prsr.Errors())
+	}
+
+	evaluated := evaluator.Evaluate(program, env)
+	if errorObject, ok := evaluated.(*object.Error); ok {
+		t.Fatalf("Evaluation of %q failed: %s", input, errorObject.Message)
+	}
+	return evaluated
+}
+
+// This helper function saves env and loads it back
+func roundTrip(t *testing.T, env *object.Environment) *object.Environment {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if err := Save(&buffer, env); err != nil {
+		t.Fatalf("Save returned an unexpected error: %s", err)
+	}
+
+	restored, err := Load(&buffer)
+	if err != nil {
+		t.Fatalf("Load returned an unexpected error: %s", err)
+	}
+	return restored
+}
+
+func TestRoundTrip(t *testing.T) {
+	env := object.NewEnvironment()
+	testEvaluate(t, `
+	let answer = 42
+	let name = "monkey"
+	let flags = (true, false, null)
+	let fib = fn(n) { if (n < 2) { n } else { fib(n - 1) + fib(n - 2) } }
+	let counter = fn() {
+		let count = 0
+		(fn() { count += 1 }, fn() { count })
+	}
+	let (inc, get) = counter()
+	inc(); inc()
+	struct Point { x, y }
+	let origin = Point { x: 0, y: 0 }
+	class Animal { init(self, name) { self.name = name } speak(self) { self.name + " makes a sound" } }
+	class Dog(Animal) { speak(self) { super.speak() + " and barks" } }
+	let rex = Dog("rex")
+	let speak = rex.speak
+	let twice = (x => x * 2) >> (x => x + 1)
+	let describe = fn(value) { match (value) { (0, y) => "on the y axis", _ => "elsewhere" } }
+	let shout = "abc".upper
+	let background = fn(x) { await(spawn (y => y * 3)(x)) }
+	let first = fn(a, b) { select { receive(a) as v => v, receive(b) as v => v } }
+	let pair = fn() { yield 1; yield 2 }
+	`, env)
+
+	restored := roundTrip(t, env)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"answer", "42"},
+		{"name", "monkey"},
+		{"flags", "(true, false, null)"},
+		{"flags == flags", "true"},
+		{"let (a, b, c) = flags; a == true", "true"},
+		{"fib(15)", "610"},
+		{"get()", "2"},
+		{"inc(); get()", "3"},
+		{"origin", "Point{x: 0, y: 0}"},
+		{"Point { x: 1, ..origin }", "Point{x: 1, y: 0}"},
+		{"rex", "Dog{name: rex}"},
+		{"rex.speak()", "rex makes a sound and barks"},
+		{"speak()", "rex makes a sound and barks"},
+		{"Dog(\"fido\").name", "fido"},
+		{"twice(4)", "9"},
+		{"describe((0, 5))", "on the y axis"},
+		{"shout()", "ABC"},
+		{"background(5)", "15"},
+		{"let ch = channel(1); send(ch, 7); first(ch, channel())", "7"},
+		{"let g = pair(); g.next() + g.next()", "3"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvaluate(t, tt.input, object.NewEnclosedEnvironment(restored))
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("Incorrect result for %q! Expected '%s' but instead received '%s'", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestRoundTripPreservesSharing(t *testing.T) {
+	env := object.NewEnvironment()
+	testEvaluate(t, `
+	let total = 0
+	let add = fn(n) { total += n }
+	let read = fn() { total }
+	let pair = (add, add)`, env)
+
+	restored := roundTrip(t, env)
+
+	add, _ := restored.Get("add")
+	read, _ := restored.Get("read")
+	pair, _ := restored.Get("pair")
+
+	if add.(*object.Function).Env != read.(*object.Function).Env {
+		t.Errorf("Closures which shared an environment no longer share it")
+	}
+
+	if pair.(*object.Tuple).Elements[0] != add || pair.(*object.Tuple).Elements[1] != add {
+		t.Errorf("Values referred to twice were restored as copies")
+	}
+
+	testEvaluate(t, "add(5)", restored)
+	if value := testEvaluate(t, "read()", restored); value.Inspect() != "5" {
+		t.Errorf("Closures do not share their restored environment! Received %s", value.Inspect())
+	}
+}
+
+func TestRoundTripIsStable(t *testing.T) {
+	env := object.NewEnvironment()
+	testEvaluate(t, `
+	class Box { init(self, v) { self.v = v } get(self) { self.v } }
+	let b = Box(1)
+	let f = fn(x) { if (x > 1) { "big" } else { "small" } }`, env)
+
+	var first, second bytes.Buffer
+	if err := Save(&first, env); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(&second, roundTrip(t, env)); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.String() != second.String() {
+		t.Errorf("Saving a restored environment produced a different snapshot")
+	}
+}
+
+func TestSnapshotFrozenEnvironment(t *testing.T) {
+	shared := object.NewEnvironment()
+	testEvaluate(t, "let x = 1", shared)
+	shared.Freeze()
+
+	child := object.NewEnclosedEnvironment(shared)
+	testEvaluate(t, "let y = x + 1", child)
+
+	restored := roundTrip(t, child)
+	if restored.Frozen() || !restored.Outer().Frozen() {
+		t.Errorf("Frozen environments were not restored as frozen")
+	}
+
+	if value := testEvaluate(t, "x + y", restored); value.Inspect() != "3" {
+		t.Errorf("Incorrect result! Expected 3 but instead received %s", value.Inspect())
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	env := object.NewEnvironment()
+	env.Set("length", &object.BuiltIn{Function: func(args ...object.Object) object.Object { return nil }})
+
+	var buffer bytes.Buffer
+	err := Save(&buffer, env)
+	if err == nil || err.Error() != "snapshot: cannot save a value of type BUILTIN (bound to length)" {
+		t.Errorf("Incorrect error saving a builtin! Received '%v'", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"version": 99, "root": 0}`, "snapshot: unsupported version 99, expected 1"},
+		{`{"version": 1, "root": 3, "environments": [], "objects": []}`, "snapshot: invalid environment 3"},
+		{`{"version": 1, "root": -1, "environments": [], "objects": []}`, "snapshot: missing root environment"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": [{"name": "x", "object": 7}]}], "objects": []}`, "snapshot: invalid value 7"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": [{"name": "x", "object": -1}]}], "objects": []}`, "snapshot: missing value"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": []}], "objects": [{"type": "TUPLE", "elements": [-1]}]}`, "snapshot: missing value"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": []}], "objects": [{"type": "MODULE", "name": "m", "fields": [{"name": "x", "object": -1}]}]}`, "snapshot: missing value"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": []}], "objects": [{"type": "INTEGER", "integer": 1}, {"type": "COMPOSITION", "refs": [0, -1]}]}`, "snapshot: invalid references in a value of type COMPOSITION"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": []}], "objects": [{"type": "BUILTIN"}]}`, "snapshot: cannot restore a value of type BUILTIN"},
+		{`{"version": 1, "root": 0, "environments": [{"outer": -1, "bindings": []}], "objects": [{"type": "FUNCTION", "body": 0, "env": 0}], "bodies": [{"node": "Nonsense"}]}`, "snapshot: expected BlockStatement but found Nonsense"},
+		{`not json`, "snapshot: invalid character 'o' in literal null (expecting 'u')"},
+	}
+
+	for _, tt := range tests {
+		_, err := Load(strings.NewReader(tt.input))
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("Incorrect error loading %s! Expected '%s' but instead received '%v'", tt.input, tt.expected, err)
+		}
+	}
+}
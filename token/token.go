@@ -15,6 +15,10 @@ const (
 
 	// Operators
 	ASSIGN   = "="
+	PLUS_EQ  = "+="
+	MINUS_EQ = "-="
+	STAR_EQ  = "*="
+	SLASH_EQ = "/="
 	PLUS     = "+"
 	MINUS    = "-"
 	ASTERISK = "*"
@@ -24,10 +28,19 @@ const (
 	GT       = ">"
 	EQ       = "=="
 	NOT_EQ   = "!="
+	ARROW    = "=>"
+	PIPE     = "|"
+	QUESTION = "?"
+	COALESCE = "??"
+	PIPELINE = "|>"
+	COMPOSE  = ">>"
 
 	// Delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
+	DOT       = "."
+	SPREAD    = ".."
 
 	LPAREN = "("
 	LBRACE = "{"
@@ -42,6 +55,16 @@ const (
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
 	RETURN   = "RETURN"
+	MATCH    = "MATCH"
+	NULL     = "NULL"
+	STRUCT   = "STRUCT"
+	CLASS    = "CLASS"
+	IMPORT   = "IMPORT"
+	EXPORT   = "EXPORT"
+	AS       = "AS"
+	SPAWN    = "SPAWN"
+	SELECT   = "SELECT"
+	YIELD    = "YIELD"
 )
 
 // Token data structure
@@ -58,6 +81,16 @@ var keywords = map[string]TokenType{
 	"true":   TRUE,
 	"false":  FALSE,
 	"return": RETURN,
+	"match":  MATCH,
+	"null":   NULL,
+	"struct": STRUCT,
+	"class":  CLASS,
+	"import": IMPORT,
+	"export": EXPORT,
+	"as":     AS,
+	"spawn":  SPAWN,
+	"select": SELECT,
+	"yield":  YIELD,
 }
 
 func LookupIdentifier(identifier string) TokenType {